@@ -5,6 +5,9 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
 	"strings"
 
 	"github.com/Alge/aligned/internal/config"
@@ -15,20 +18,35 @@ import (
 )
 
 func check(args []string, stdout, stderr io.Writer) int {
-	// Check for verbose flag
+	// Check for verbose flag and filter options
 	verbose := false
+	strictInterfaces := false
 	specPath := ""
+	var filter sectionFilter
 	
-	for _, arg := range args {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		consumed, err := filter.parseArg(args, i)
+		if err != nil {
+			fmt.Fprintf(stderr, "Error: %v\n", err)
+			return 1
+		}
+		if consumed > 0 {
+			i += consumed - 1
+			continue
+		}
+		
 		if arg == "-v" || arg == "--verbose" {
 			verbose = true
+		} else if arg == "--strict-interfaces" {
+			strictInterfaces = true
 		} else if !strings.HasPrefix(arg, "-") {
 			specPath = arg
 		}
 	}
 	
 	if specPath == "" {
-		fmt.Fprintln(stderr, "Usage: align check [-v] <spec-file-or-directory>")
+		fmt.Fprintln(stderr, "Usage: align check [-v] [--strict-interfaces] [--tag <tag>] [--exclude-tag <tag>] [--owner <name>] [--status <status>] <spec-file-or-directory>")
 		return 1
 	}
 	
@@ -51,7 +69,7 @@ func check(args []string, stdout, stderr io.Writer) int {
 	}
 	
 	// Load specification (file or directory)
-	specification, err := loadSpecification(specPath)
+	specification, err := loadSpecification(specPath, directoryOptions(cfg))
 	if err != nil {
 		if os.IsNotExist(err) {
 			fmt.Fprintf(stderr, "Error: Spec path not found: %s\n", specPath)
@@ -61,8 +79,12 @@ func check(args []string, stdout, stderr io.Writer) int {
 		return 1
 	}
 	
-	// Discover all tests
-	var allTests []string
+	// Filters narrow what is checked, but interfaces are resolved against the whole tree
+	fullSpecification := specification
+	specification = filter.apply(specification)
+	
+	// Discover all tests, remembering which connectors found each one
+	discovered := make(discoveredTests)
 	for _, connectorCfg := range cfg.Connectors {
 		var connector connectors.Connector
 
@@ -108,19 +130,25 @@ func check(args []string, stdout, stderr io.Writer) int {
 			return 1
 		}
 		
-		allTests = append(allTests, tests...)
+		discovered.add(connectorCfg.Type, tests)
 	}
 	
 	// Create a set of discovered tests for quick lookup
-	testSet := make(map[string]bool)
-	for _, test := range allTests {
-		testSet[test] = true
-	}
+	testSet := discovered.testSet()
 	
 	// Check coverage
 	hasErrors := false
 	missingReferences := []string{}
 	testsNotFound := []string{}
+	missingJustifications := []string{}
+	missingReasons := []string{}
+	ambiguousReferences := []string{}
+	ignoredReferences := []string{}
+	unverifiedSubtests := []string{}
+	notApplicable := []*spec.Section{}
+	lifecycleCounts := make(map[spec.Lifecycle]int)
+	deprecatedWithTests := []string{}
+	var failures []checkFailure
 	
 	log := logger.Debug()
 	
@@ -141,25 +169,115 @@ func check(args []string, stdout, stderr io.Writer) int {
 		
 		// Use RequiresTest() to handle interfaces properly
 		if leaf.RequiresTest() {
+			unverifiedSubtests = append(unverifiedSubtests, unverifiedReferences(leaf, testSet)...)
 			if !leaf.HasTest() {
 				missingReferences = append(missingReferences, leaf.Title)
+				failures = append(failures, checkFailure{leaf.Location(), "Missing test reference: " + leaf.Title})
 				hasErrors = true
 				log.Debug("missing test reference", "title", leaf.Title)
-			} else if !testSet[leaf.TestName] {
-				testsNotFound = append(testsNotFound, leaf.TestName)
+			} else if !isSectionCovered(leaf, testSet) {
+				for i, ref := range testReferenceStatuses(leaf, testSet) {
+					if !ref.Found && !ref.Ambiguous {
+						testsNotFound = append(testsNotFound, ref.Name)
+						failures = append(failures, checkFailure{leaf.TestReferenceLocation(i), ref.problem()})
+						log.Debug("test not found", "title", leaf.Title, "testName", ref.Name)
+					}
+				}
 				hasErrors = true
-				log.Debug("test not found", "title", leaf.Title, "testName", leaf.TestName)
+			}
+			
+			// An unqualified name found by several connectors may be satisfied by the wrong one
+			for i, name := range leaf.Tests() {
+				if connectorTypes := discovered.ambiguous(name); connectorTypes != nil {
+					ambiguousReferences = append(ambiguousReferences, name)
+					failures = append(failures, checkFailure{
+						leaf.TestReferenceLocation(i),
+						fmt.Sprintf("Ambiguous test reference %s (found by %s), qualify it as %s:%s", name, strings.Join(connectorTypes, ", "), connectorTypes[0], name),
+					})
+					hasErrors = true
+					log.Debug("ambiguous test reference", "title", leaf.Title, "testName", name)
+				}
 			}
 		} else {
 			log.Debug("test not required", "title", leaf.Title)
 		}
+		
+		// Draft, deprecated, manual, won't-fix and not applicable sections are reported separately
+		lifecycle := leaf.Lifecycle()
+		if lifecycle != spec.LifecycleActive && !isInterfaceSection(leaf) {
+			lifecycleCounts[lifecycle]++
+			switch {
+			case lifecycle == spec.LifecycleManual && leaf.Justification == "":
+				missingJustifications = append(missingJustifications, leaf.Title)
+				failures = append(failures, checkFailure{leaf.Location(), "Manual specification missing justification: " + leaf.Title})
+				hasErrors = true
+				log.Debug("manual section missing justification", "title", leaf.Title)
+			case lifecycle == spec.LifecycleNotApplicable:
+				notApplicable = append(notApplicable, leaf)
+				if leaf.NotApplicableReason() == "" {
+					missingReasons = append(missingReasons, leaf.Title)
+					failures = append(failures, checkFailure{leaf.Location(), "Not applicable specification missing reason: " + leaf.Title})
+					hasErrors = true
+					log.Debug("not applicable section missing reason", "title", leaf.Title)
+				}
+			case lifecycle == spec.LifecycleDeprecated && leaf.HasTest():
+				deprecatedWithTests = append(deprecatedWithTests, leaf.Title)
+				log.Debug("deprecated section references tests", "title", leaf.Title)
+			}
+		}
+	}
+	
+	// Only leaves are checked, so a test reference on a section whose task items,
+	// table rows or examples became sub-requirements would silently be dropped
+	for _, section := range sectionsIgnoringTests(specification) {
+		for i, name := range section.Tests() {
+			ignoredReferences = append(ignoredReferences, name)
+			failures = append(failures, checkFailure{
+				section.TestReferenceLocation(i),
+				fmt.Sprintf("Test reference %s is ignored, %s has sub-requirements", name, section.Title),
+			})
+		}
+		hasErrors = true
+		log.Debug("test reference on a section with sub-requirements", "title", section.Title)
 	}
 	
 	// Validate interface implementations
-	interfaceErrors := specification.ValidateInterfaces()
-	if len(interfaceErrors) > 0 {
+	var validationErrors []spec.InterfaceError
+	if strictInterfaces || cfg.StrictInterfaces {
+		validationErrors = fullSpecification.ValidateInterfacesStrict()
+	} else {
+		validationErrors = fullSpecification.ValidateInterfaces()
+	}
+	if filter.active() {
+		validationErrors = interfaceErrorsWithin(validationErrors, specification)
+	}
+	if len(validationErrors) > 0 {
 		hasErrors = true
-		log.Debug("interface validation errors", "count", len(interfaceErrors))
+		log.Debug("interface validation errors", "count", len(validationErrors))
+		for _, validationError := range validationErrors {
+			failures = append(failures, checkFailure{validationError.Section.Location(), validationError.Error()})
+		}
+	}
+	interfaceErrors := interfaceErrorsBySection(validationErrors)
+	
+	// Requirement IDs must be unique across the whole specification, even when filtering
+	duplicateIDs := fullSpecification.DuplicateIDs()
+	if filter.active() {
+		duplicateIDs = duplicateIDsWithin(duplicateIDs, specification)
+	}
+	if len(duplicateIDs) > 0 {
+		hasErrors = true
+		log.Debug("duplicate requirement IDs", "count", len(duplicateIDs))
+		for _, id := range sortedKeys(duplicateIDs) {
+			// Report every use after the first, pointing back at the original
+			first := duplicateIDs[id][0]
+			for _, section := range duplicateIDs[id][1:] {
+				failures = append(failures, checkFailure{
+					section.Location(),
+					fmt.Sprintf("Duplicate requirement ID %s (first used by %s)", id, describeSection(first)),
+				})
+			}
+		}
 	}
 	
 	// Report results
@@ -176,7 +294,51 @@ func check(args []string, stdout, stderr io.Writer) int {
 	
 	fmt.Fprintln(stdout, "")
 	
+	// Lifecycle summary - informational, only manual sections without justification fail
+	if n := lifecycleCounts[spec.LifecycleDraft]; n > 0 {
+		fmt.Fprintf(stdout, "%s%d draft specifications (not enforced)%s\n", colorYellow, n, colorReset)
+	}
+	if n := lifecycleCounts[spec.LifecycleManual]; n > 0 {
+		fmt.Fprintf(stdout, "%s%d manually verified specifications%s\n", colorGray, n, colorReset)
+	}
+	if n := lifecycleCounts[spec.LifecycleWontFix]; n > 0 {
+		fmt.Fprintf(stdout, "%s%d won't fix specifications%s\n", colorGray, n, colorReset)
+	}
+	if n := lifecycleCounts[spec.LifecycleDeprecated]; n > 0 {
+		fmt.Fprintf(stdout, "%s%d deprecated specifications%s\n", colorGray, n, colorReset)
+	}
+	if len(notApplicable) > 0 {
+		fmt.Fprintf(stdout, "%s%d not applicable specifications%s\n", colorGray, len(notApplicable), colorReset)
+		// Verbose output lists what was waived, so reviewers can see why
+		if verbose {
+			for _, section := range notApplicable {
+				reason := section.NotApplicableReason()
+				if reason == "" {
+					reason = colorRed + "missing reason" + colorReset
+				}
+				fmt.Fprintf(stdout, "  %s: %s\n", section.Title, reason)
+			}
+		}
+	}
+	if len(unverifiedSubtests) > 0 {
+		fmt.Fprintf(stdout, "%s%d subtest references unverified, only their parent tests were found%s\n", colorYellow, len(unverifiedSubtests), colorReset)
+	}
+	if len(deprecatedWithTests) > 0 {
+		fmt.Fprintf(stdout, "%sWarning: %d deprecated specifications still reference tests:%s\n", colorYellow, len(deprecatedWithTests), colorReset)
+		for _, title := range deprecatedWithTests {
+			fmt.Fprintf(stdout, "  %s\n", title)
+		}
+	}
+	
 	if hasErrors {
+		// One line per failure in file:line form, so editors and CI logs can link to it
+		for _, failure := range failures {
+			fmt.Fprintln(stdout, failure.String())
+		}
+		if len(failures) > 0 {
+			fmt.Fprintln(stdout, "")
+		}
+		
 		if len(missingReferences) > 0 {
 			fmt.Fprintf(stdout, "%s%d specifications missing test references%s\n", colorRed, len(missingReferences), colorReset)
 		}
@@ -185,10 +347,37 @@ func check(args []string, stdout, stderr io.Writer) int {
 			fmt.Fprintf(stdout, "%s%d test references not found%s\n", colorRed, len(testsNotFound), colorReset)
 		}
 		
-		if len(interfaceErrors) > 0 {
-			fmt.Fprintf(stdout, "%s%d interface implementation errors:%s\n", colorRed, len(interfaceErrors), colorReset)
-			for impl, missing := range interfaceErrors {
-				fmt.Fprintf(stdout, "  %s is missing: %s\n", impl, strings.Join(missing, ", "))
+		if len(ambiguousReferences) > 0 {
+			fmt.Fprintf(stdout, "%s%d ambiguous test references%s\n", colorRed, len(ambiguousReferences), colorReset)
+		}
+		
+		if len(ignoredReferences) > 0 {
+			fmt.Fprintf(stdout, "%s%d test references on sections with sub-requirements%s\n", colorRed, len(ignoredReferences), colorReset)
+		}
+		
+		if len(missingJustifications) > 0 {
+			fmt.Fprintf(stdout, "%s%d manual specifications missing justification%s\n", colorRed, len(missingJustifications), colorReset)
+		}
+		
+		if len(missingReasons) > 0 {
+			fmt.Fprintf(stdout, "%s%d not applicable specifications missing reason%s\n", colorRed, len(missingReasons), colorReset)
+		}
+		
+		if len(validationErrors) > 0 {
+			fmt.Fprintf(stdout, "%s%d interface implementation errors:%s\n", colorRed, len(validationErrors), colorReset)
+			for _, validationError := range validationErrors {
+				fmt.Fprintf(stdout, "  %s\n", validationError.Error())
+			}
+		}
+		
+		if len(duplicateIDs) > 0 {
+			fmt.Fprintf(stdout, "%s%d duplicate requirement IDs:%s\n", colorRed, len(duplicateIDs), colorReset)
+			for _, id := range sortedKeys(duplicateIDs) {
+				var titles []string
+				for _, section := range duplicateIDs[id] {
+					titles = append(titles, section.Title)
+				}
+				fmt.Fprintf(stdout, "  %s is used by: %s\n", id, strings.Join(titles, ", "))
 			}
 		}
 		
@@ -199,30 +388,159 @@ func check(args []string, stdout, stderr io.Writer) int {
 	return 0
 }
 
-func loadSpecification(path string) (*spec.Specification, error) {
+func loadSpecification(path string, options parser.DirectoryOptions) (*spec.Specification, error) {
 	info, err := os.Stat(path)
 	if err != nil {
 		return nil, err
 	}
 	
+	var specification *spec.Specification
 	if !info.IsDir() {
 		// Single file
-		content, err := os.ReadFile(path)
-		if err != nil {
-			return nil, err
+		specification, err = parser.ParseFile(path)
+	} else {
+		// Directory - use the new ParseDirectory function
+		specification, err = parser.ParseDirectoryWithOptions(path, options)
+	}
+	if err != nil {
+		return nil, err
+	}
+	
+	// Implementations may rely on interface test patterns instead of explicit
+	// references, and all references may be written relative to a **Test prefix:**
+	specification.ApplyTestPatterns()
+	specification.ApplyTestPrefixes()
+	return specification, nil
+}
+
+// directoryOptions returns which files of a spec directory the configuration selects
+func directoryOptions(cfg *config.Configuration) parser.DirectoryOptions {
+	return parser.DirectoryOptions{
+		Extensions: cfg.Spec.Extensions,
+		Exclude:    cfg.Spec.Exclude,
+	}
+}
+
+// discoveredTests records which connector types discovered each test
+type discoveredTests map[string][]string
+
+func (d discoveredTests) add(connectorType string, tests []string) {
+	for _, test := range tests {
+		if !slices.Contains(d[test], connectorType) {
+			d[test] = append(d[test], connectorType)
 		}
-		
-		specification, err := parser.ParseMarkdown(string(content))
-		if err != nil {
-			return nil, err
+	}
+}
+
+// testSet returns every discovered test name. Each test can also be referenced
+// as "connector:name", which only matches the tests of that connector type. A
+// name discovered by several connector types is ambiguous and maps to false.
+func (d discoveredTests) testSet() map[string]bool {
+	testSet := make(map[string]bool)
+	for test, connectorTypes := range d {
+		testSet[test] = len(connectorTypes) == 1
+		for _, connectorType := range connectorTypes {
+			testSet[connectorType+":"+test] = true
 		}
-		specification.FilePath = path
-		
-		return specification, nil
+	}
+	return testSet
+}
+
+// ambiguous returns the connector types that discovered a test name, if more
+// than one did. Qualified names are never ambiguous.
+func (d discoveredTests) ambiguous(name string) []string {
+	if connectorTypes := d[name]; len(connectorTypes) > 1 {
+		return connectorTypes
+	}
+	return nil
+}
+
+// checkFailure is a single problem found by check, tied to a place in the spec files
+type checkFailure struct {
+	location string // "file:line", or empty if unknown
+	message  string
+}
+
+func (f checkFailure) String() string {
+	if f.location == "" {
+		return fmt.Sprintf("%s%s%s", colorRed, f.message, colorReset)
+	}
+	return fmt.Sprintf("%s: %s%s%s", f.location, colorRed, f.message, colorReset)
+}
+
+// describeSection names a section together with its location, if known
+func describeSection(section *spec.Section) string {
+	if location := section.Location(); location != "" {
+		return fmt.Sprintf("%s at %s", section.Title, location)
+	}
+	return section.Title
+}
+
+// sortedKeys returns the keys of a map in sorted order for stable output
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// interfaceErrorsWithin keeps only the interface errors of sections present in the specification
+func interfaceErrorsWithin(interfaceErrors []spec.InterfaceError, specification *spec.Specification) []spec.InterfaceError {
+	present := make(map[string]bool)
+	var walk func(*spec.Section)
+	walk = func(section *spec.Section) {
+		present[section.Title] = true
+		for _, child := range section.Children {
+			walk(child)
+		}
+	}
+	for _, root := range specification.Sections {
+		walk(root)
 	}
 	
-	// Directory - use the new ParseDirectory function
-	return parser.ParseDirectory(path)
+	var kept []spec.InterfaceError
+	for _, interfaceError := range interfaceErrors {
+		if present[interfaceError.Section.Title] {
+			kept = append(kept, interfaceError)
+		}
+	}
+	return kept
+}
+
+// duplicateIDsWithin keeps only the duplicate IDs used by a section present in the specification
+func duplicateIDsWithin(duplicateIDs map[string][]*spec.Section, specification *spec.Specification) map[string][]*spec.Section {
+	present := make(map[string]bool)
+	var walk func(*spec.Section)
+	walk = func(section *spec.Section) {
+		present[section.ID] = true
+		for _, child := range section.Children {
+			walk(child)
+		}
+	}
+	for _, root := range specification.Sections {
+		walk(root)
+	}
+	
+	kept := make(map[string][]*spec.Section)
+	for id, sections := range duplicateIDs {
+		if present[id] {
+			kept[id] = sections
+		}
+	}
+	return kept
+}
+
+// interfaceErrorsBySection maps the titles of sections with interface errors to
+// their error messages, for marking those sections in the printed tree
+func interfaceErrorsBySection(interfaceErrors []spec.InterfaceError) map[string][]string {
+	bySection := make(map[string][]string)
+	for _, interfaceError := range interfaceErrors {
+		title := interfaceError.Section.Title
+		bySection[title] = append(bySection[title], interfaceError.Error())
+	}
+	return bySection
 }
 
 // printSpecificationCollapsedWithErrors is a wrapper that passes interface errors
@@ -242,29 +560,18 @@ func printSectionCollapsedWithErrors(section *spec.Section, indent int, testSet
 	// Count total and passing specs in this section
 	total, passing := countSectionCoverage(section, testSet)
 	
-	var statusIcon string
-	if sectionHasError {
-		statusIcon = fmt.Sprintf("%s✗%s", colorRed, colorReset)
-	} else {
-		statusIcon = fmt.Sprintf("%s✓%s", colorGreen, colorReset)
-	}
+	statusIcon := sectionStatusIcon(section, sectionHasError, testSet)
 	
 	// Print section title
-	fmt.Fprintf(stdout, "%s%s %s%s%s", prefix, statusIcon, colorBlue, section.Title, colorReset)
+	fmt.Fprintf(stdout, "%s%s %s%s", prefix, statusIcon, sectionLabel(section), colorReset)
 	
 	if section.IsLeaf() {
 		// Leaf sections show their test info only if they require tests
 		if section.RequiresTest() {
-			if !section.HasTest() {
-				fmt.Fprintf(stdout, " %s(Missing test reference)%s\n", colorRed, colorReset)
-			} else if !testSet[section.TestName] {
-				fmt.Fprintf(stdout, " %s(Test not found: %s)%s\n", colorRed, section.TestName, colorReset)
-			} else {
-				fmt.Fprintf(stdout, " %s(%s)%s\n", colorGray, section.TestName, colorReset)
-			}
+			printLeafTestStatus(section, indent, testSet, stdout)
 		} else {
-			// Interface leaf sections don't need tests
-			fmt.Fprintln(stdout, "")
+			// Interface and lifecycle leaf sections don't need tests
+			printLeafLifecycleStatus(section, stdout)
 		}
 		return
 	}
@@ -299,32 +606,18 @@ func printSectionWithStatusAndErrors(section *spec.Section, indent int, testSet
 	
 	// Determine section status (including interface errors)
 	sectionHasError := checkSectionHasErrorWithInterface(section, testSet, interfaceErrors)
-	var statusIcon string
-	if sectionHasError {
-		statusIcon = fmt.Sprintf("%s✗%s", colorRed, colorReset)
-	} else {
-		statusIcon = fmt.Sprintf("%s✓%s", colorGreen, colorReset)
-	}
+	statusIcon := sectionStatusIcon(section, sectionHasError, testSet)
 	
 	// Print section title with status icon
-	fmt.Fprintf(stdout, "%s%s %s%s%s", prefix, statusIcon, colorBlue, section.Title, colorReset)
+	fmt.Fprintf(stdout, "%s%s %s%s", prefix, statusIcon, sectionLabel(section), colorReset)
 	
 	// Additional info for leaf sections
 	if section.IsLeaf() {
 		if section.RequiresTest() {
-			if !section.HasTest() {
-				// Missing test reference
-				fmt.Fprintf(stdout, " %s(Missing test reference)%s\n", colorRed, colorReset)
-			} else if !testSet[section.TestName] {
-				// Test not found
-				fmt.Fprintf(stdout, " %s(Test not found: %s)%s\n", colorRed, section.TestName, colorReset)
-			} else {
-				// Test found
-				fmt.Fprintf(stdout, " %s(%s)%s\n", colorGray, section.TestName, colorReset)
-			}
+			printLeafTestStatus(section, indent, testSet, stdout)
 		} else {
-			// Interface leaf sections don't need tests
-			fmt.Fprintln(stdout, "")
+			// Interface and lifecycle leaf sections don't need tests
+			printLeafLifecycleStatus(section, stdout)
 		}
 	} else {
 		// Non-leaf section
@@ -337,6 +630,30 @@ func printSectionWithStatusAndErrors(section *spec.Section, indent int, testSet
 	}
 }
 
+// sectionStatusIcon returns ✗ for a section with errors, a yellow ? for a leaf
+// whose tests were only found through the parents of Go subtests, and ✓ otherwise
+func sectionStatusIcon(section *spec.Section, hasError bool, testSet map[string]bool) string {
+	if hasError {
+		return fmt.Sprintf("%s✗%s", colorRed, colorReset)
+	}
+	if section.IsLeaf() && section.RequiresTest() && len(unverifiedReferences(section, testSet)) > 0 {
+		return fmt.Sprintf("%s?%s", colorYellow, colorReset)
+	}
+	return fmt.Sprintf("%s✓%s", colorGreen, colorReset)
+}
+
+// unverifiedReferences returns the test references of a section that were only
+// found through the parent of a Go subtest
+func unverifiedReferences(section *spec.Section, testSet map[string]bool) []string {
+	var names []string
+	for _, status := range testReferenceStatuses(section, testSet) {
+		if status.Unverified {
+			names = append(names, status.Name)
+		}
+	}
+	return names
+}
+
 // checkSectionHasErrorWithInterface checks for both test errors and interface errors
 func checkSectionHasErrorWithInterface(section *spec.Section, testSet map[string]bool, interfaceErrors map[string][]string) bool {
 	// Check if this is an implementation with validation errors
@@ -345,10 +662,8 @@ func checkSectionHasErrorWithInterface(section *spec.Section, testSet map[string
 	}
 	
 	// Check if this section itself has a test error
-	if section.IsLeaf() && section.RequiresTest() {
-		if !section.HasTest() || !testSet[section.TestName] {
-			return true
-		}
+	if leafHasError(section, testSet) || ignoresTestReference(section) {
+		return true
 	}
 	
 	// Check all children recursively
@@ -382,7 +697,7 @@ func printSectionWithStatus(section *spec.Section, indent int, testSet map[strin
 func countSectionCoverage(section *spec.Section, testSet map[string]bool) (total int, passing int) {
 	if section.IsLeaf() && section.RequiresTest() {
 		total = 1
-		if section.HasTest() && testSet[section.TestName] {
+		if isSectionCovered(section, testSet) {
 			passing = 1
 		}
 		return
@@ -399,10 +714,8 @@ func countSectionCoverage(section *spec.Section, testSet map[string]bool) (total
 // checkSectionHasError returns true if this section or any of its descendants has an error
 func checkSectionHasError(section *spec.Section, testSet map[string]bool) bool {
 	// Check if this section itself has an error
-	if section.IsLeaf() && section.RequiresTest() {
-		if !section.HasTest() || !testSet[section.TestName] {
-			return true
-		}
+	if leafHasError(section, testSet) || ignoresTestReference(section) {
+		return true
 	}
 	
 	// Check all children recursively
@@ -413,4 +726,341 @@ func checkSectionHasError(section *spec.Section, testSet map[string]bool) bool {
 	}
 	
 	return false
-}
\ No newline at end of file
+}
+
+// testReferenceStatus pairs a test reference with whether it was discovered
+type testReferenceStatus struct {
+	Name        string
+	Found       bool
+	Unverified  bool     // Found through its parent test, as go test -list does not report subtests
+	Ambiguous   bool     // Discovered by several connector types, so it must be qualified
+	Pattern     bool     // Glob or re: reference, found when it matches enough tests
+	Matches     []string // Discovered tests matched by a pattern reference
+	Needed      int      // Tests a pattern reference must match
+	Uncountable bool     // A subtest pattern with a minimum, which go test -list cannot count
+	Err         error    // Set if the pattern is not a valid regular expression
+}
+
+// problem describes why the reference was not found
+func (s testReferenceStatus) problem() string {
+	switch {
+	case s.Err != nil:
+		return fmt.Sprintf("Invalid test pattern %s: %v", s.Name, s.Err)
+	case s.Uncountable:
+		return fmt.Sprintf("Cannot count %d matches of %s, go test -list does not report subtests", s.Needed, s.Name)
+	case s.Pattern && len(s.Matches) == 0:
+		return "No tests match " + s.Name
+	case s.Pattern:
+		return fmt.Sprintf("Only %d tests match %s, expected at least %d", len(s.Matches), s.Name, s.Needed)
+	case s.Ambiguous:
+		return "Ambiguous test reference: " + s.Name
+	}
+	return "Test not found: " + s.Name
+}
+
+// testReferenceStatuses looks up every test reference of a section
+func testReferenceStatuses(section *spec.Section, testSet map[string]bool) []testReferenceStatus {
+	var statuses []testReferenceStatus
+	for _, name := range section.Tests() {
+		if !isTestPatternReference(name) {
+			found, discovered := testSet[name]
+			status := testReferenceStatus{Name: name, Found: found, Ambiguous: discovered && !found}
+			if !status.Found && section.TestExpanded {
+				// Expanded examples often name Go subtests, which can only be traced to their parent
+				status.Found = isParentTestDiscovered(name, testSet)
+				status.Unverified = status.Found
+			}
+			statuses = append(statuses, status)
+			continue
+		}
+
+		status := testReferenceStatus{Name: name, Pattern: true, Needed: max(section.MinMatches, 1)}
+		if parent, ok := subtestPatternParent(name); ok {
+			// Subtests are not discovered, so only their parent test can be listed
+			if testSet[parent] {
+				status.Matches = []string{parent}
+			}
+			status.Uncountable = status.Needed > 1
+			status.Found = len(status.Matches) > 0 && !status.Uncountable
+			status.Unverified = status.Found
+			statuses = append(statuses, status)
+			continue
+		}
+		status.Matches, status.Err = matchingTests(name, testSet)
+		status.Found = status.Err == nil && len(status.Matches) >= status.Needed
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+// regexpTestPrefix marks a test reference as a regular expression
+const regexpTestPrefix = "re:"
+
+// isTestPatternReference returns true if the reference names a family of tests
+// rather than a single one: a glob with * wildcards, or a re: regular expression
+func isTestPatternReference(name string) bool {
+	return strings.HasPrefix(name, regexpTestPrefix) || strings.Contains(name, "*")
+}
+
+// subtestPatternParent returns the parent test of a glob over Go subtests such
+// as pkg.TestParse/*. Go subtests are not discovered, so such a pattern can only
+// be checked against its parent test.
+func subtestPatternParent(name string) (string, bool) {
+	if strings.HasPrefix(name, regexpTestPrefix) {
+		return "", false
+	}
+	matches := goSubtestPattern.FindStringSubmatch(name)
+	if matches == nil || strings.Contains(matches[1], "*") {
+		return "", false
+	}
+	return matches[1], true
+}
+
+// globQualifierPattern splits a connector qualifier such as go: off a glob
+var globQualifierPattern = regexp.MustCompile(`^([a-z]+:)([^:].*)$`)
+
+// testPatternRegexp compiles a pattern reference. A glob must match the end of
+// the test name starting at its beginning or after a /, . or :: boundary, with *
+// matching any characters, so parser.Test* and TestParse* both match
+// Alge/aligned/internal/parser.TestParse. A re: expression is unanchored, like
+// go test -run.
+func testPatternRegexp(name string) (*regexp.Regexp, error) {
+	if expression, ok := strings.CutPrefix(name, regexpTestPrefix); ok {
+		return regexp.Compile(expression)
+	}
+	qualifier := ""
+	if matches := globQualifierPattern.FindStringSubmatch(name); matches != nil {
+		qualifier, name = matches[1], matches[2]
+	}
+	parts := strings.Split(name, "*")
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(part)
+	}
+	return regexp.Compile("^" + regexp.QuoteMeta(qualifier) + "(?:.*(?:/|\\.|::))?" + strings.Join(parts, ".*") + "$")
+}
+
+// matchingTests returns the discovered tests that a pattern reference matches,
+// sorted. A test matched both by its name and as connector:name is listed once.
+func matchingTests(name string, testSet map[string]bool) ([]string, error) {
+	pattern, err := testPatternRegexp(name)
+	if err != nil {
+		return nil, err
+	}
+
+	matched := make(map[string]bool)
+	for test, found := range testSet {
+		if found && pattern.MatchString(test) {
+			matched[test] = true
+		}
+	}
+
+	var matches []string
+	for test := range matched {
+		if _, unqualified, ok := strings.Cut(test, ":"); ok && matched[unqualified] {
+			continue
+		}
+		matches = append(matches, test)
+	}
+	sort.Strings(matches)
+	return matches, nil
+}
+
+// goSubtestPattern splits a Go subtest reference such as pkg.TestParse/empty
+// into its parent test and the subtest name
+var goSubtestPattern = regexp.MustCompile(`^(.*?\.(?:Test|Fuzz|Example)\w*)/.+$`)
+
+// isParentTestDiscovered returns true if the name is a Go subtest whose parent
+// test was discovered. go test -list only reports top-level tests, so whether
+// the subtest itself exists is unknown.
+func isParentTestDiscovered(name string, testSet map[string]bool) bool {
+	if matches := goSubtestPattern.FindStringSubmatch(name); matches != nil {
+		return testSet[matches[1]]
+	}
+	return false
+}
+
+// isSectionCovered returns true if the section's test references satisfy its match mode:
+// all references must be found by default, or at least one for "any of" sections
+func isSectionCovered(section *spec.Section, testSet map[string]bool) bool {
+	statuses := testReferenceStatuses(section, testSet)
+	if len(statuses) == 0 {
+		return false
+	}
+
+	found := 0
+	for _, status := range statuses {
+		if status.Found {
+			found++
+		}
+	}
+
+	if section.MatchesAny() {
+		return found > 0
+	}
+	return found == len(statuses)
+}
+
+// printLeafTestStatus prints the test status of a leaf section that requires tests.
+// A single reference is shown inline; multiple references get one line each.
+func printLeafTestStatus(section *spec.Section, indent int, testSet map[string]bool, stdout io.Writer) {
+	statuses := testReferenceStatuses(section, testSet)
+
+	if len(statuses) == 0 {
+		fmt.Fprintf(stdout, " %s(Missing test reference)%s\n", colorRed, colorReset)
+		return
+	}
+
+	if len(statuses) == 1 {
+		status := statuses[0]
+		if !status.Found {
+			fmt.Fprintf(stdout, " %s(%s)%s\n", colorRed, status.problem(), colorReset)
+		} else if status.Unverified {
+			fmt.Fprintf(stdout, " %s(%s: parent found, subtest unverified)%s\n", colorYellow, status.Name, colorReset)
+		} else if status.Pattern {
+			fmt.Fprintf(stdout, " %s(%s: %d matching tests)%s\n", colorGray, status.Name, len(status.Matches), colorReset)
+		} else {
+			fmt.Fprintf(stdout, " %s(%s)%s\n", colorGray, status.Name, colorReset)
+		}
+		printPatternMatches(status, indent+1, stdout)
+		return
+	}
+
+	found := 0
+	for _, status := range statuses {
+		if status.Found {
+			found++
+		}
+	}
+
+	mode := "all of"
+	if section.MatchesAny() {
+		mode = "any of"
+	}
+	color := colorGray
+	if !isSectionCovered(section, testSet) {
+		color = colorRed
+	}
+	fmt.Fprintf(stdout, " %s(%d/%d tests found, %s)%s\n", color, found, len(statuses), mode, colorReset)
+
+	prefix := colorGray + strings.Repeat("· ", indent+1) + colorReset
+	for _, status := range statuses {
+		if status.Unverified {
+			fmt.Fprintf(stdout, "%s  %s? %s (parent found, subtest unverified)%s\n", prefix, colorYellow, status.Name, colorReset)
+		} else if status.Found {
+			fmt.Fprintf(stdout, "%s  %s✓ %s%s\n", prefix, colorGreen, status.Name, colorReset)
+		} else {
+			fmt.Fprintf(stdout, "%s  %s✗ %s%s\n", prefix, colorRed, status.problem(), colorReset)
+		}
+		printPatternMatches(status, indent+2, stdout)
+	}
+}
+
+// printPatternMatches lists the tests matched by a pattern reference below it
+func printPatternMatches(status testReferenceStatus, indent int, stdout io.Writer) {
+	prefix := colorGray + strings.Repeat("· ", indent) + colorReset
+	for _, match := range status.Matches {
+		fmt.Fprintf(stdout, "%s  %s%s%s\n", prefix, colorGray, match, colorReset)
+	}
+}
+
+// ignoresTestReference returns true if the section references tests but its
+// task items, table rows or examples became its children, so its references
+// are never checked. Heading parents with a test reference are left alone.
+func ignoresTestReference(section *spec.Section) bool {
+	return section.HasItems && section.HasTest()
+}
+
+// sectionsIgnoringTests returns the sections whose test references are ignored, in document order
+func sectionsIgnoringTests(specification *spec.Specification) []*spec.Section {
+	var sections []*spec.Section
+	var walk func(*spec.Section)
+	walk = func(section *spec.Section) {
+		if ignoresTestReference(section) {
+			sections = append(sections, section)
+		}
+		for _, child := range section.Children {
+			walk(child)
+		}
+	}
+	for _, root := range specification.Sections {
+		walk(root)
+	}
+	return sections
+}
+
+// isInterfaceSection returns true if the section is an interface or part of one
+func isInterfaceSection(section *spec.Section) bool {
+	for current := section; current != nil; current = current.Parent {
+		if current.IsInterface() {
+			return true
+		}
+	}
+	return false
+}
+
+// leafHasError returns true if a leaf section fails on its own: a required test is
+// missing or not found, a manual section has no justification, or a not applicable
+// section has no reason
+func leafHasError(section *spec.Section, testSet map[string]bool) bool {
+	if !section.IsLeaf() {
+		return false
+	}
+	if section.RequiresTest() {
+		if !isSectionCovered(section, testSet) {
+			return true
+		}
+		// An ambiguous reference is an error even when another test covers an "any of" section
+		for _, status := range testReferenceStatuses(section, testSet) {
+			if status.Ambiguous {
+				return true
+			}
+		}
+		return false
+	}
+	if isInterfaceSection(section) {
+		return false
+	}
+	switch section.Lifecycle() {
+	case spec.LifecycleManual:
+		return section.Justification == ""
+	case spec.LifecycleNotApplicable:
+		return section.NotApplicableReason() == ""
+	}
+	return false
+}
+
+// printLeafLifecycleStatus ends the line of a leaf that does not require a test,
+// describing its lifecycle if it has one
+func printLeafLifecycleStatus(section *spec.Section, stdout io.Writer) {
+	if isInterfaceSection(section) {
+		fmt.Fprintln(stdout, "")
+		return
+	}
+
+	switch section.Lifecycle() {
+	case spec.LifecycleDraft:
+		fmt.Fprintf(stdout, " %s(Draft)%s\n", colorYellow, colorReset)
+	case spec.LifecycleDeprecated:
+		if section.HasTest() {
+			fmt.Fprintf(stdout, " %s(Deprecated, still references: %s)%s\n", colorYellow, strings.Join(section.Tests(), ", "), colorReset)
+		} else {
+			fmt.Fprintf(stdout, " %s(Deprecated)%s\n", colorGray, colorReset)
+		}
+	case spec.LifecycleManual:
+		if section.Justification == "" {
+			fmt.Fprintf(stdout, " %s(Manual: missing justification)%s\n", colorRed, colorReset)
+		} else {
+			fmt.Fprintf(stdout, " %s(Manual: %s)%s\n", colorGray, section.Justification, colorReset)
+		}
+	case spec.LifecycleWontFix:
+		fmt.Fprintf(stdout, " %s(Won't fix)%s\n", colorGray, colorReset)
+	case spec.LifecycleNotApplicable:
+		if reason := section.NotApplicableReason(); reason == "" {
+			fmt.Fprintf(stdout, " %s(Not applicable: missing reason)%s\n", colorRed, colorReset)
+		} else {
+			fmt.Fprintf(stdout, " %s(Not applicable: %s)%s\n", colorGray, reason, colorReset)
+		}
+	default:
+		fmt.Fprintln(stdout, "")
+	}
+}
@@ -476,6 +476,7 @@ func TestAllConnectorsRegisteredInInit(t *testing.T) {
 		"python-pytest",
 		"elixir-exunit",
 		"gleam-gleeunit",
+		"javascript-vitest",
 	}
 
 	for _, connectorType := range expectedConnectors {
@@ -607,4 +608,1210 @@ func TestVitestConnectorRegisteredInCheck(t *testing.T) {
 	stderrStr := stderr.String()
 	assert.NotContains(t, strings.ToLower(stderrStr), "unsupported connector type",
 		"vitest connector should be registered in check command")
-}
\ No newline at end of file
+}
+
+func TestCheckMultipleTestReferences(t *testing.T) {
+	testFiles := map[string]string{
+		"example_test.go": `package example
+import "testing"
+func TestUnit(t *testing.T) {}
+func TestIntegration(t *testing.T) {}
+func TestLegacy(t *testing.T) {}
+`,
+	}
+
+	t.Run("all of passes when every test exists", func(t *testing.T) {
+		specContent := `# Test Spec
+
+## Feature
+**Tests:**
+- ` + "`testproject.TestUnit`" + `
+- ` + "`testproject.TestIntegration`" + `
+`
+		tempDir, specPath := setupTestProject(t, testFiles, specContent)
+
+		originalDir, _ := os.Getwd()
+		defer os.Chdir(originalDir)
+		os.Chdir(tempDir)
+
+		var stdout, stderr bytes.Buffer
+		exitCode := run([]string{"check", "-v", specPath}, &stdout, &stderr)
+
+		assert.Equal(t, 0, exitCode)
+		output := stdout.String()
+		assert.Contains(t, output, "2/2 tests found, all of")
+		assert.Contains(t, output, "testproject.TestUnit")
+		assert.Contains(t, output, "testproject.TestIntegration")
+	})
+
+	t.Run("all of fails when one test is missing", func(t *testing.T) {
+		specContent := `# Test Spec
+
+## Feature
+**Test:** ` + "`testproject.TestUnit`" + `
+**Test:** ` + "`testproject.TestMissing`" + `
+`
+		tempDir, specPath := setupTestProject(t, testFiles, specContent)
+
+		originalDir, _ := os.Getwd()
+		defer os.Chdir(originalDir)
+		os.Chdir(tempDir)
+
+		var stdout, stderr bytes.Buffer
+		exitCode := run([]string{"check", specPath}, &stdout, &stderr)
+
+		assert.Equal(t, 1, exitCode)
+		output := stdout.String()
+		assert.Contains(t, output, "1/2 tests found, all of")
+		assert.Contains(t, output, "spec.md:5: "+colorRed+"Test not found: testproject.TestMissing", "failures point at the missing reference's own line")
+		assert.Contains(t, output, "1 test references not found")
+	})
+
+	t.Run("any of passes when one test exists", func(t *testing.T) {
+		specContent := `# Test Spec
+
+## Feature
+**Tests (any of):** ` + "`testproject.TestMissing`, `testproject.TestLegacy`" + `
+`
+		tempDir, specPath := setupTestProject(t, testFiles, specContent)
+
+		originalDir, _ := os.Getwd()
+		defer os.Chdir(originalDir)
+		os.Chdir(tempDir)
+
+		var stdout, stderr bytes.Buffer
+		exitCode := run([]string{"check", "-v", specPath}, &stdout, &stderr)
+
+		assert.Equal(t, 0, exitCode)
+		output := stdout.String()
+		assert.Contains(t, output, "1/2 tests found, any of")
+		assert.Contains(t, output, "Test not found: testproject.TestMissing")
+	})
+}
+
+func TestCheckDuplicateRequirementIDs(t *testing.T) {
+	testFiles := map[string]string{
+		"example_test.go": `package example
+import "testing"
+func TestOne(t *testing.T) {}
+func TestTwo(t *testing.T) {}
+`,
+	}
+
+	tempDir, _ := setupTestProject(t, testFiles, "")
+
+	// The duplicate spans two files of a directory tree
+	specDir := filepath.Join(tempDir, "specs")
+	err := os.MkdirAll(specDir, 0755)
+	assert.NoError(t, err)
+	err = os.WriteFile(filepath.Join(specDir, "one.md"), []byte("---\nowner: security-team\n---\n# One\n\n## First {#REQ-1}\n**Test:** `testproject.TestOne`\n"), 0644)
+	assert.NoError(t, err)
+	err = os.WriteFile(filepath.Join(specDir, "two.md"), []byte("# Two\n\n## Second {#REQ-1}\n**Test:** `testproject.TestTwo`\n"), 0644)
+	assert.NoError(t, err)
+
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+	os.Chdir(tempDir)
+
+	var stdout, stderr bytes.Buffer
+	exitCode := run([]string{"check", specDir}, &stdout, &stderr)
+
+	assert.Equal(t, 1, exitCode, "should fail when a requirement ID is used twice")
+	output := stdout.String()
+	assert.Contains(t, output, "1 duplicate requirement IDs")
+	assert.Contains(t, output, "REQ-1 is used by: First, Second")
+	assert.NotContains(t, output, "{#REQ-1}", "ID marker should be stripped from titles")
+
+	// A filter does not hide a duplicate in a section it leaves out
+	stdout.Reset()
+	exitCode = run([]string{"check", "--owner", "security-team", specDir}, &stdout, &stderr)
+	assert.Equal(t, 1, exitCode)
+	assert.Contains(t, stdout.String(), "REQ-1 is used by: First, Second")
+}
+
+func TestCheckFiltersByMetadata(t *testing.T) {
+	testFiles := map[string]string{
+		"example_test.go": `package example
+import "testing"
+func TestLogin(t *testing.T) {}
+`,
+	}
+
+	tempDir, _ := setupTestProject(t, testFiles, "")
+
+	specDir := filepath.Join(tempDir, "specs")
+	err := os.MkdirAll(specDir, 0755)
+	assert.NoError(t, err)
+	err = os.WriteFile(filepath.Join(specDir, "auth.md"),
+		[]byte("---\nowner: security-team\n---\n# Auth\n\n## Login\n**Test:** `testproject.TestLogin`\n"), 0644)
+	assert.NoError(t, err)
+	// Billing has no tests yet, but is owned by another team
+	err = os.WriteFile(filepath.Join(specDir, "billing.md"),
+		[]byte("---\nowner: billing-team\n---\n# Billing\n\n## Invoice\nNot tested yet.\n"), 0644)
+	assert.NoError(t, err)
+
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+	os.Chdir(tempDir)
+
+	var stdout, stderr bytes.Buffer
+	exitCode := run([]string{"check", "--owner", "security-team", specDir}, &stdout, &stderr)
+	assert.Equal(t, 0, exitCode, "only sections owned by security-team should be checked")
+	assert.NotContains(t, stdout.String(), "Billing")
+
+	stdout.Reset()
+	exitCode = run([]string{"check", specDir}, &stdout, &stderr)
+	assert.Equal(t, 1, exitCode, "without a filter the untested billing section fails")
+}
+
+func TestCheckLifecycleMarkers(t *testing.T) {
+	testFiles := map[string]string{
+		"example_test.go": `package example
+import "testing"
+func TestFeature(t *testing.T) {}
+func TestOldFeature(t *testing.T) {}
+`,
+	}
+
+	t.Run("lifecycle sections do not fail check", func(t *testing.T) {
+		specContent := `# Test Spec
+
+## Feature
+**Test:** ` + "`testproject.TestFeature`" + `
+
+## Upcoming feature [DRAFT]
+Not written yet.
+
+## Old feature [DEPRECATED]
+**Test:** ` + "`testproject.TestOldFeature`" + `
+
+## Printed manual [MANUAL]
+**Justification:** Reviewed by the docs team before every release
+
+## IE6 support [WONTFIX]
+Not supported.
+`
+		tempDir, specPath := setupTestProject(t, testFiles, specContent)
+
+		originalDir, _ := os.Getwd()
+		defer os.Chdir(originalDir)
+		os.Chdir(tempDir)
+
+		var stdout, stderr bytes.Buffer
+		exitCode := run([]string{"check", "-v", specPath}, &stdout, &stderr)
+
+		assert.Equal(t, 0, exitCode, "draft, deprecated, manual and won't fix sections should not fail")
+		output := stdout.String()
+		assert.Contains(t, output, "(Draft)")
+		assert.Contains(t, output, "Manual: Reviewed by the docs team before every release")
+		assert.Contains(t, output, "Won't fix")
+		assert.Contains(t, output, "1 draft specifications")
+		assert.Contains(t, output, "Warning: 1 deprecated specifications still reference tests")
+		assert.Contains(t, output, "Old feature [DEPRECATED]")
+	})
+
+	t.Run("manual section without justification fails", func(t *testing.T) {
+		specContent := `# Test Spec
+
+## Printed manual [MANUAL]
+Checked by hand.
+`
+		tempDir, specPath := setupTestProject(t, testFiles, specContent)
+
+		originalDir, _ := os.Getwd()
+		defer os.Chdir(originalDir)
+		os.Chdir(tempDir)
+
+		var stdout, stderr bytes.Buffer
+		exitCode := run([]string{"check", specPath}, &stdout, &stderr)
+
+		assert.Equal(t, 1, exitCode)
+		output := stdout.String()
+		assert.Contains(t, output, "Manual: missing justification")
+		assert.Contains(t, output, "1 manual specifications missing justification")
+	})
+}
+
+func TestCheckFiltersByTag(t *testing.T) {
+	testFiles := map[string]string{
+		"example_test.go": `package example
+import "testing"
+func TestLogin(t *testing.T) {}
+func TestTokens(t *testing.T) {}
+`,
+	}
+
+	specContent := `# Test Spec
+
+## Authentication [TAGS: security]
+
+### Login
+**Test:** ` + "`testproject.TestLogin`" + `
+
+### Tokens
+**Tags:** slow
+**Test:** ` + "`testproject.TestTokens`" + `
+
+## Reporting
+
+### Export
+Not tested yet.
+`
+
+	tempDir, specPath := setupTestProject(t, testFiles, specContent)
+
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+	os.Chdir(tempDir)
+
+	t.Run("tag", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		exitCode := run([]string{"check", "-v", "--tag", "security", specPath}, &stdout, &stderr)
+
+		assert.Equal(t, 0, exitCode, "only security-tagged sections should be checked")
+		output := stdout.String()
+		assert.Contains(t, output, "Login")
+		assert.Contains(t, output, "Tokens", "tags are inherited from the parent heading")
+		assert.NotContains(t, output, "Export")
+	})
+
+	t.Run("exclude tag", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		exitCode := run([]string{"check", "-v", "--tag=security", "--exclude-tag=slow", specPath}, &stdout, &stderr)
+
+		assert.Equal(t, 0, exitCode)
+		output := stdout.String()
+		assert.Contains(t, output, "Login")
+		assert.NotContains(t, output, "Tokens")
+	})
+
+	t.Run("no filter checks everything", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		exitCode := run([]string{"check", specPath}, &stdout, &stderr)
+
+		assert.Equal(t, 1, exitCode)
+		assert.Contains(t, stdout.String(), "Export")
+	})
+}
+
+func TestCheckReportsFailureLocations(t *testing.T) {
+	testFiles := map[string]string{
+		"example_test.go": `package example
+import "testing"
+func TestExists(t *testing.T) {}
+`,
+	}
+
+	specContent := "# Feature\n\n" +
+		"## Covered\n" +
+		"**Test:** `testproject.TestExists`\n\n" +
+		"## Broken\n" +
+		"Refers to a test that was renamed.\n\n" +
+		"**Test:** `testproject.TestRenamed`\n\n" +
+		"## Untested\n" +
+		"No reference yet.\n"
+
+	tempDir, specPath := setupTestProject(t, testFiles, specContent)
+
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+	os.Chdir(tempDir)
+
+	var stdout, stderr bytes.Buffer
+	exitCode := run([]string{"check", specPath}, &stdout, &stderr)
+
+	assert.Equal(t, 1, exitCode)
+	output := stdout.String()
+	assert.Contains(t, output, specPath+":9: "+colorRed+"Test not found: testproject.TestRenamed")
+	assert.Contains(t, output, specPath+":11: "+colorRed+"Missing test reference: Untested")
+}
+
+func TestCheckNestedInterfaceValidation(t *testing.T) {
+	specContent := "# Framework\n\n" +
+		"## Connector [INTERFACE]\n\n" +
+		"### Command Integration\n\n" +
+		"#### Register in init command\n\n" +
+		"#### Register in check command\n\n" +
+		"## Go Connector [IMPLEMENTS: Connector]\n\n" +
+		"### Command Integration\n\n" +
+		"#### Register in check command\n" +
+		"**Test:** `testproject.TestCheck`\n"
+
+	testFiles := map[string]string{
+		"impl_test.go": `package impl
+import "testing"
+func TestCheck(t *testing.T) {}
+`,
+	}
+
+	tempDir, specPath := setupTestProject(t, testFiles, specContent)
+
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+	os.Chdir(tempDir)
+
+	var stdout, stderr bytes.Buffer
+	exitCode := run([]string{"check", specPath}, &stdout, &stderr)
+
+	assert.Equal(t, 1, exitCode, "a missing nested interface section should fail the check")
+	assert.Contains(t, stdout.String(), "is missing: command integration > register in init command")
+}
+
+func TestCheckInterfaceInheritance(t *testing.T) {
+	specContent := "# Framework\n\n" +
+		"## Connector [INTERFACE]\n\n" +
+		"### Discover tests\n\n" +
+		"## HTTP Connector [INTERFACE EXTENDS: Connector]\n\n" +
+		"### Send requests\n\n" +
+		"## Cached Connector [INTERFACE EXTENDS: Missing Base]\n\n" +
+		"### Cache results\n\n" +
+		"## REST Connector [IMPLEMENTS: HTTP Connector]\n\n" +
+		"### Send requests\n" +
+		"**Test:** `testproject.TestSend`\n"
+
+	testFiles := map[string]string{
+		"impl_test.go": `package impl
+import "testing"
+func TestSend(t *testing.T) {}
+`,
+	}
+
+	tempDir, specPath := setupTestProject(t, testFiles, specContent)
+
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+	os.Chdir(tempDir)
+
+	var stdout, stderr bytes.Buffer
+	exitCode := run([]string{"check", specPath}, &stdout, &stderr)
+
+	assert.Equal(t, 1, exitCode)
+	output := stdout.String()
+	assert.Contains(t, output, "REST Connector [IMPLEMENTS: HTTP Connector] is missing: discover tests")
+	assert.Contains(t, output, "Cached Connector [INTERFACE EXTENDS: Missing Base]: extends unknown interface 'Missing Base'")
+	assert.NotContains(t, output, "Missing test reference: Cache results", "sections of extending interfaces do not need tests")
+}
+
+func TestCheckMultipleInterfaces(t *testing.T) {
+	specContent := "# Framework\n\n" +
+		"## Connector [INTERFACE]\n\n" +
+		"### Discover tests\n\n" +
+		"## Configurable [INTERFACE]\n\n" +
+		"### Generate default configuration\n\n" +
+		"## Go Connector [IMPLEMENTS: Connector, Configurable]\n\n" +
+		"### Discover tests\n" +
+		"**Test:** `testproject.TestDiscover`\n"
+
+	testFiles := map[string]string{
+		"impl_test.go": `package impl
+import "testing"
+func TestDiscover(t *testing.T) {}
+`,
+	}
+
+	tempDir, specPath := setupTestProject(t, testFiles, specContent)
+
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+	os.Chdir(tempDir)
+
+	var stdout, stderr bytes.Buffer
+	exitCode := run([]string{"check", specPath}, &stdout, &stderr)
+
+	assert.Equal(t, 1, exitCode, "every listed interface must be satisfied")
+	output := stdout.String()
+	assert.Contains(t, output, "is missing from Configurable: generate default configuration")
+	assert.NotContains(t, output, "is missing from Connector")
+}
+
+func TestCheckStrictInterfaces(t *testing.T) {
+	specContent := "# Framework\n\n" +
+		"## Database [INTERFACE]\n\n" +
+		"### Connect to database\n\n" +
+		"## Postgres [IMPLEMENTS: Database]\n\n" +
+		"### Connect to database\n" +
+		"**Test:** `testproject.TestConnect`\n\n" +
+		"### Conect to databse\n" +
+		"**Test:** `testproject.TestConnect`\n"
+
+	testFiles := map[string]string{
+		"impl_test.go": `package impl
+import "testing"
+func TestConnect(t *testing.T) {}
+`,
+	}
+
+	tempDir, specPath := setupTestProject(t, testFiles, specContent)
+
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+	os.Chdir(tempDir)
+
+	t.Run("extra sections pass by default", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		exitCode := run([]string{"check", specPath}, &stdout, &stderr)
+		assert.Equal(t, 0, exitCode)
+	})
+
+	t.Run("flag enables strict mode", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		exitCode := run([]string{"check", "--strict-interfaces", specPath}, &stdout, &stderr)
+		assert.Equal(t, 1, exitCode)
+		assert.Contains(t, stdout.String(), "has unexpected sections: conect to databse (did you mean 'connect to database'?)")
+	})
+
+	t.Run("configuration enables strict mode", func(t *testing.T) {
+		configContent := "connectors:\n  - type: go\n    path: .\nstrict_interfaces: true\n"
+		err := os.WriteFile(filepath.Join(tempDir, ".align.yml"), []byte(configContent), 0644)
+		assert.NoError(t, err)
+
+		var stdout, stderr bytes.Buffer
+		exitCode := run([]string{"check", specPath}, &stdout, &stderr)
+		assert.Equal(t, 1, exitCode)
+		assert.Contains(t, stdout.String(), "has unexpected sections")
+	})
+}
+
+func TestCheckOptionalInterfaceSections(t *testing.T) {
+	specContent := "# Framework\n\n" +
+		"## Connector [INTERFACE]\n\n" +
+		"### Discover tests\n\n" +
+		"### Support watch mode [OPTIONAL]\n\n" +
+		"## Go Connector [IMPLEMENTS: Connector]\n\n" +
+		"### Discover tests\n" +
+		"**Test:** `testproject.TestDiscover`\n\n" +
+		"## Vitest Connector [IMPLEMENTS: Connector]\n\n" +
+		"### Discover tests\n" +
+		"**Test:** `testproject.TestDiscover`\n\n" +
+		"### Support watch mode\n" +
+		"Reruns tests when files change.\n"
+
+	testFiles := map[string]string{
+		"impl_test.go": `package impl
+import "testing"
+func TestDiscover(t *testing.T) {}
+`,
+	}
+
+	tempDir, specPath := setupTestProject(t, testFiles, specContent)
+
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+	os.Chdir(tempDir)
+
+	var stdout, stderr bytes.Buffer
+	exitCode := run([]string{"check", specPath}, &stdout, &stderr)
+
+	assert.Equal(t, 1, exitCode, "an included optional section still needs a test")
+	output := stdout.String()
+	assert.NotContains(t, output, "interface implementation errors", "omitting an optional section is not an error")
+	assert.Contains(t, output, "Missing test reference: Support watch mode")
+}
+
+func TestCheckNotApplicableSections(t *testing.T) {
+	testFiles := map[string]string{
+		"impl_test.go": `package impl
+import "testing"
+func TestDiscover(t *testing.T) {}
+`,
+	}
+
+	interfaceSpec := "# Framework\n\n" +
+		"## Connector [INTERFACE]\n\n" +
+		"### Discover tests\n\n" +
+		"### Handle empty test suite\n\n"
+
+	t.Run("waived sections with a reason pass and are counted", func(t *testing.T) {
+		specContent := interfaceSpec +
+			"## Gleam Connector [IMPLEMENTS: Connector]\n\n" +
+			"### Discover tests\n" +
+			"**Test:** `testproject.TestDiscover`\n\n" +
+			"### Handle empty test suite [N/A]\n" +
+			"**Reason:** Gleam always generates at least one test module\n"
+
+		tempDir, specPath := setupTestProject(t, testFiles, specContent)
+
+		originalDir, _ := os.Getwd()
+		defer os.Chdir(originalDir)
+		os.Chdir(tempDir)
+
+		var stdout, stderr bytes.Buffer
+		exitCode := run([]string{"check", "-v", specPath}, &stdout, &stderr)
+
+		assert.Equal(t, 0, exitCode)
+		output := stdout.String()
+		assert.Contains(t, output, "(Not applicable: Gleam always generates at least one test module)")
+		assert.Contains(t, output, "1 not applicable specifications")
+		assert.Contains(t, output, "  Handle empty test suite [N/A]: Gleam always generates at least one test module")
+	})
+
+	t.Run("waived sections without a reason fail", func(t *testing.T) {
+		specContent := interfaceSpec +
+			"## Gleam Connector [IMPLEMENTS: Connector]\n\n" +
+			"### Discover tests\n" +
+			"**Test:** `testproject.TestDiscover`\n\n" +
+			"### Handle empty test suite [N/A]\n" +
+			"Not relevant.\n"
+
+		tempDir, specPath := setupTestProject(t, testFiles, specContent)
+
+		originalDir, _ := os.Getwd()
+		defer os.Chdir(originalDir)
+		os.Chdir(tempDir)
+
+		var stdout, stderr bytes.Buffer
+		exitCode := run([]string{"check", specPath}, &stdout, &stderr)
+
+		assert.Equal(t, 1, exitCode)
+		output := stdout.String()
+		assert.Contains(t, output, "Not applicable: missing reason")
+		assert.Contains(t, output, "1 not applicable specifications missing reason")
+	})
+}
+
+func TestCheckQualifiedInterfaces(t *testing.T) {
+	testFiles := map[string]string{
+		"impl_test.go": `package impl
+import "testing"
+func TestDiscover(t *testing.T) {}
+`,
+	}
+
+	tempDir, _ := setupTestProject(t, testFiles, "")
+
+	// Two teams define an interface with the same name in their own directories
+	specDir := filepath.Join(tempDir, "specs")
+	for _, dir := range []string{"integrations", "storage"} {
+		err := os.MkdirAll(filepath.Join(specDir, dir), 0755)
+		assert.NoError(t, err)
+		err = os.WriteFile(filepath.Join(specDir, dir, "connector.md"),
+			[]byte("# Connector [INTERFACE]\n\n## Discover "+dir+"\n"), 0644)
+		assert.NoError(t, err)
+	}
+
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+	os.Chdir(tempDir)
+
+	t.Run("qualified reference", func(t *testing.T) {
+		err := os.WriteFile(filepath.Join(specDir, "go.md"),
+			[]byte("# Go [IMPLEMENTS: integrations/Connector]\n\n## Discover integrations\n**Test:** `testproject.TestDiscover`\n"), 0644)
+		assert.NoError(t, err)
+
+		var stdout, stderr bytes.Buffer
+		exitCode := run([]string{"check", specDir}, &stdout, &stderr)
+		assert.Equal(t, 0, exitCode, stdout.String())
+	})
+
+	t.Run("ambiguous bare reference", func(t *testing.T) {
+		err := os.WriteFile(filepath.Join(specDir, "go.md"),
+			[]byte("# Go [IMPLEMENTS: Connector]\n\n## Discover integrations\n**Test:** `testproject.TestDiscover`\n"), 0644)
+		assert.NoError(t, err)
+
+		var stdout, stderr bytes.Buffer
+		exitCode := run([]string{"check", specDir}, &stdout, &stderr)
+		assert.Equal(t, 1, exitCode)
+		assert.Contains(t, stdout.String(), "interface name 'Connector' is ambiguous, qualify it as one of: integrations/Connector, storage/Connector")
+	})
+}
+
+func TestCheckDerivedTestPatterns(t *testing.T) {
+	specContent := "# Framework\n\n" +
+		"## Connector [INTERFACE]\n" +
+		"**Test pattern:** `testproject.Test{Impl}{Section}`\n\n" +
+		"### Discover tests\n\n" +
+		"### Report missing framework\n\n" +
+		"## Go [IMPLEMENTS: Connector]\n\n" +
+		"### Discover tests\n\n" +
+		"### Report missing framework\n"
+
+	testFiles := map[string]string{
+		"impl_test.go": `package impl
+import "testing"
+func TestGoDiscoverTests(t *testing.T) {}
+`,
+	}
+
+	tempDir, specPath := setupTestProject(t, testFiles, specContent)
+
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+	os.Chdir(tempDir)
+
+	var stdout, stderr bytes.Buffer
+	exitCode := run([]string{"check", "-v", specPath}, &stdout, &stderr)
+
+	assert.Equal(t, 1, exitCode)
+	output := stdout.String()
+	assert.Contains(t, output, "(testproject.TestGoDiscoverTests)", "derived test names are checked against discovered tests")
+	assert.Contains(t, output, "Test not found: testproject.TestGoReportMissingFramework")
+	assert.NotContains(t, output, "Missing test reference")
+}
+
+func TestCheckIncludes(t *testing.T) {
+	specContent := "# Services\n\n" +
+		"## Billing\n" +
+		"<!-- align:include shared.md -->\n\n" +
+		"## Shipping\n" +
+		"<!-- align:include shared.md -->\n"
+
+	testFiles := map[string]string{
+		"shared.md": "# Authentication\n\n" +
+			"## Reject expired tokens {#AUTH-001}\n" +
+			"**Test:** `testproject.TestExpiredTokens`\n",
+		"auth_test.go": `package auth
+import "testing"
+func TestExpiredTokens(t *testing.T) {}
+`,
+	}
+
+	tempDir, specPath := setupTestProject(t, testFiles, specContent)
+
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+	os.Chdir(tempDir)
+
+	var stdout, stderr bytes.Buffer
+	exitCode := run([]string{"check", "-v", specPath}, &stdout, &stderr)
+
+	assert.Equal(t, 0, exitCode, stdout.String()+stderr.String())
+	output := stdout.String()
+	assert.Equal(t, 2, strings.Count(output, "Reject expired tokens"), "the shared file is checked wherever it is included")
+	assert.NotContains(t, output, "Duplicate requirement ID", "including a file twice does not duplicate its IDs")
+}
+
+func TestCheckExcludesSpecFiles(t *testing.T) {
+	testFiles := map[string]string{
+		"parser_test.go": `package parser
+import "testing"
+func TestParser(t *testing.T) {}
+`,
+	}
+
+	tempDir, _ := setupTestProject(t, testFiles, "")
+
+	configContent := `connectors:
+  - type: go
+    executable: go
+    path: .
+spec:
+  exclude:
+    - CHANGELOG.md
+`
+	err := os.WriteFile(filepath.Join(tempDir, ".align.yml"), []byte(configContent), 0644)
+	assert.NoError(t, err)
+
+	specFiles := map[string]string{
+		"parser.md":       "# Parser\n**Test:** `testproject.TestParser`\n",
+		"README.md":       "# About the specs\n",
+		"CHANGELOG.md":    "# Changes\n",
+		"web/.gitkeep":    "",
+		"web/node/pkg.md": "# Vendored docs\n",
+		".alignignore":    "README.md\nweb/node/\n",
+	}
+	specDir := filepath.Join(tempDir, "specs")
+	for name, content := range specFiles {
+		path := filepath.Join(specDir, name)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+	os.Chdir(tempDir)
+
+	var stdout, stderr bytes.Buffer
+	exitCode := run([]string{"check", "specs"}, &stdout, &stderr)
+
+	assert.Equal(t, 0, exitCode, stdout.String()+stderr.String())
+	assert.NotContains(t, stdout.String(), "Missing test reference")
+
+	stdout.Reset()
+	exitCode = run([]string{"show", "specs"}, &stdout, &stderr)
+
+	assert.Equal(t, 0, exitCode)
+	assert.Contains(t, stdout.String(), "Parser")
+	assert.NotContains(t, stdout.String(), "Changes", "show honors the configured excludes")
+	assert.NotContains(t, stdout.String(), "About the specs")
+	assert.NotContains(t, stdout.String(), "Vendored docs")
+}
+
+func TestCheckGherkinFeatures(t *testing.T) {
+	testFiles := map[string]string{
+		"auth_test.go": `package auth
+import "testing"
+func TestLogin(t *testing.T) {}
+func TestWeakPassword(t *testing.T) {}
+`,
+	}
+
+	tempDir, _ := setupTestProject(t, testFiles, "")
+
+	specDir := filepath.Join(tempDir, "specs")
+	assert.NoError(t, os.MkdirAll(specDir, 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(specDir, "overview.md"), []byte("# Overview\n\n## Sign in\n**Test:** `testproject.TestLogin`\n"), 0644))
+	featureContent := `Feature: Passwords
+
+  @test:testproject.TestLogin
+  Scenario: Sign in with a password
+
+  @test:testproject.Test<name>
+  Scenario Outline: Reject <name>
+    Examples:
+      | name           |
+      | WeakPassword   |
+      | ReusedPassword |
+
+  Scenario: Expire old passwords
+`
+	assert.NoError(t, os.WriteFile(filepath.Join(specDir, "passwords.feature"), []byte(featureContent), 0644))
+
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+	os.Chdir(tempDir)
+
+	var stdout, stderr bytes.Buffer
+	exitCode := run([]string{"check", "specs"}, &stdout, &stderr)
+
+	assert.Equal(t, 1, exitCode)
+	output := stdout.String()
+	assert.Contains(t, output, filepath.Join("specs", "passwords.feature")+":11: "+colorRed+"Test not found: testproject.TestReusedPassword")
+	assert.Contains(t, output, filepath.Join("specs", "passwords.feature")+":13: "+colorRed+"Missing test reference: Expire old passwords")
+	assert.NotContains(t, output, "Test not found: testproject.TestWeakPassword", "rows with existing tests pass")
+}
+
+func TestCheckTaskListCriteria(t *testing.T) {
+	specContent := "# Login\n\n" +
+		"## Password rules\n" +
+		"**Test:** `testproject.TestPasswordRules`\n" +
+		"- [ ] Rejects empty password `testproject.TestRejectEmpty`\n" +
+		"- [ ] Rejects short password `testproject.TestRejectShort`\n" +
+		"- [ ] Shows a strength hint\n\n" +
+		"## Sessions\n" +
+		"**Test:** `testproject.TestPasswordRules`\n\n" +
+		"### Remember me\n" +
+		"**Test:** `testproject.TestRejectEmpty`\n"
+
+	testFiles := map[string]string{
+		"login_test.go": `package login
+import "testing"
+func TestRejectEmpty(t *testing.T) {}
+func TestPasswordRules(t *testing.T) {}
+`,
+	}
+
+	tempDir, specPath := setupTestProject(t, testFiles, specContent)
+
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+	os.Chdir(tempDir)
+
+	var stdout, stderr bytes.Buffer
+	exitCode := run([]string{"check", "-v", specPath}, &stdout, &stderr)
+
+	assert.Equal(t, 1, exitCode)
+	output := stdout.String()
+	assert.Contains(t, output, "Rejects empty password"+colorReset+" "+colorGray+"(testproject.TestRejectEmpty)")
+	assert.Contains(t, output, "spec.md:6: "+colorRed+"Test not found: testproject.TestRejectShort")
+	assert.Contains(t, output, "spec.md:7: "+colorRed+"Missing test reference: Shows a strength hint")
+	assert.NotContains(t, output, "Missing test reference: Password rules", "the criteria are checked instead of their section")
+	assert.Contains(t, output, "spec.md:4: "+colorRed+"Test reference testproject.TestPasswordRules is ignored, Password rules has sub-requirements")
+	assert.Contains(t, output, "1 test references on sections with sub-requirements")
+	assert.NotContains(t, output, "Sessions has sub-requirements", "heading parents may keep their test references")
+}
+
+func TestCheckRequirementTables(t *testing.T) {
+	specContent := "# Login\n\n" +
+		"## Behavior matrix\n" +
+		"| Requirement | Test |\n" +
+		"|-------------|------|\n" +
+		"| Rejects empty password | `testproject.TestRejectEmpty` |\n" +
+		"| Rejects short password | `testproject.TestRejectShort` |\n" +
+		"| Shows a strength hint | |\n"
+
+	testFiles := map[string]string{
+		"login_test.go": `package login
+import "testing"
+func TestRejectEmpty(t *testing.T) {}
+`,
+	}
+
+	tempDir, specPath := setupTestProject(t, testFiles, specContent)
+
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+	os.Chdir(tempDir)
+
+	var stdout, stderr bytes.Buffer
+	exitCode := run([]string{"check", "-v", specPath}, &stdout, &stderr)
+
+	assert.Equal(t, 1, exitCode)
+	output := stdout.String()
+	assert.Contains(t, output, "Rejects empty password"+colorReset+" "+colorGray+"(testproject.TestRejectEmpty)")
+	assert.Contains(t, output, "spec.md:7: "+colorRed+"Test not found: testproject.TestRejectShort")
+	assert.Contains(t, output, "spec.md:8: "+colorRed+"Missing test reference: Shows a strength hint")
+	assert.NotContains(t, output, "Missing test reference: Behavior matrix")
+}
+
+func TestCheckParametrizedRequirements(t *testing.T) {
+	specContent := "# Parser\n\n" +
+		"## Parse numbers\n" +
+		"**Test:** `testproject.TestParse/{case}`\n\n" +
+		"| case | input |\n" +
+		"|------|-------|\n" +
+		"| integer | 42 |\n" +
+		"| negative | -1 |\n\n" +
+		"## Format numbers\n" +
+		"**Test:** `testproject.TestFormat/{case}`\n\n" +
+		"| case |\n" +
+		"|------|\n" +
+		"| integer |\n\n" +
+		"## Parse by hand\n" +
+		"**Test:** `testproject.TestParse/no_such_case`\n"
+
+	testFiles := map[string]string{
+		"parser_test.go": `package parser
+import "testing"
+func TestParse(t *testing.T) {
+	for _, name := range []string{"integer", "negative"} {
+		t.Run(name, func(t *testing.T) {})
+	}
+}
+`,
+	}
+
+	tempDir, specPath := setupTestProject(t, testFiles, specContent)
+
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+	os.Chdir(tempDir)
+
+	var stdout, stderr bytes.Buffer
+	exitCode := run([]string{"check", "-v", specPath}, &stdout, &stderr)
+
+	assert.Equal(t, 1, exitCode)
+	output := stdout.String()
+	assert.Contains(t, output, colorYellow+"?"+colorReset+" "+colorBlue+"negative"+colorReset+" "+colorYellow+"(testproject.TestParse/negative: parent found, subtest unverified)", "expanded Go subtests are traced to their parent test")
+	assert.Contains(t, output, "2 subtest references unverified")
+	assert.Contains(t, output, "spec.md:16: "+colorRed+"Test not found: testproject.TestFormat/integer")
+	assert.Contains(t, output, "spec.md:19: "+colorRed+"Test not found: testproject.TestParse/no_such_case", "hand-written subtest references must be discovered")
+	assert.Contains(t, output, "2 test references not found", "each example is checked on its own")
+}
+
+func TestDiscoveredTests(t *testing.T) {
+	discovered := make(discoveredTests)
+	discovered.add("go", []string{"api.TestLogin", "shared_name"})
+	discovered.add("pytest", []string{"tests/test_api.py::test_login", "shared_name"})
+	discovered.add("pytest", []string{"shared_name"})
+
+	testSet := discovered.testSet()
+	assert.True(t, testSet["api.TestLogin"])
+	assert.True(t, testSet["go:api.TestLogin"])
+	assert.False(t, testSet["pytest:api.TestLogin"], "qualified names only match their own connector")
+	assert.True(t, testSet["pytest:tests/test_api.py::test_login"])
+	found, present := testSet["shared_name"]
+	assert.True(t, present && !found, "names discovered by several connector types are ambiguous")
+	assert.True(t, testSet["go:shared_name"])
+
+	assert.Equal(t, []string{"go", "pytest"}, discovered.ambiguous("shared_name"))
+	assert.Nil(t, discovered.ambiguous("api.TestLogin"))
+	assert.Nil(t, discovered.ambiguous("go:shared_name"))
+}
+
+func TestCheckConnectorQualifiedReferences(t *testing.T) {
+	specContent := "# API\n\n" +
+		"## Login from Go\n" +
+		"**Test:** `go:testproject.TestLogin`\n\n" +
+		"## Login from Python\n" +
+		"**Test:** `pytest:tests/test_api.py::test_login`\n\n" +
+		"## Login from the wrong language\n" +
+		"**Test:** `pytest:testproject.TestLogin`\n"
+
+	testFiles := map[string]string{
+		"api_test.go": `package api
+import "testing"
+func TestLogin(t *testing.T) {}
+`,
+		"fake-pytest": "#!/bin/sh\necho 'tests/test_api.py::test_login'\n",
+	}
+
+	tempDir, specPath := setupTestProject(t, testFiles, specContent)
+	assert.NoError(t, os.Chmod(filepath.Join(tempDir, "fake-pytest"), 0755))
+
+	configContent := `connectors:
+  - type: go
+    executable: go
+    path: .
+  - type: pytest
+    executable: ./fake-pytest
+    path: .
+`
+	err := os.WriteFile(filepath.Join(tempDir, ".align.yml"), []byte(configContent), 0644)
+	assert.NoError(t, err)
+
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+	os.Chdir(tempDir)
+
+	var stdout, stderr bytes.Buffer
+	exitCode := run([]string{"check", "-v", specPath}, &stdout, &stderr)
+
+	assert.Equal(t, 1, exitCode, stderr.String())
+	output := stdout.String()
+	assert.Contains(t, output, "(go:testproject.TestLogin)")
+	assert.Contains(t, output, "(pytest:tests/test_api.py::test_login)")
+	assert.Contains(t, output, "spec.md:10: "+colorRed+"Test not found: pytest:testproject.TestLogin")
+	assert.Contains(t, output, "1 test references not found")
+}
+
+func TestMatchingTests(t *testing.T) {
+	discovered := make(discoveredTests)
+	discovered.add("go", []string{"Alge/aligned/internal/parser.TestParseMarkdown", "Alge/aligned/internal/parser.TestParseMarkdownTables", "Alge/aligned/internal/parser.TestParseGherkin"})
+	testSet := discovered.testSet()
+	markdownTests := []string{"Alge/aligned/internal/parser.TestParseMarkdown", "Alge/aligned/internal/parser.TestParseMarkdownTables"}
+
+	matches, err := matchingTests("Alge/aligned/internal/parser.TestParseMarkdown*", testSet)
+	assert.NoError(t, err)
+	assert.Equal(t, markdownTests, matches, "qualified names are not listed twice")
+
+	matches, err = matchingTests("internal/parser.TestParseMarkdown*", testSet)
+	assert.NoError(t, err)
+	assert.Equal(t, markdownTests, matches, "globs match from a path boundary")
+
+	matches, err = matchingTests("parser.TestParseMarkdown*", testSet)
+	assert.NoError(t, err)
+	assert.Equal(t, markdownTests, matches)
+
+	matches, err = matchingTests("TestParseMarkdown*", testSet)
+	assert.NoError(t, err)
+	assert.Equal(t, markdownTests, matches, "globs match from a package boundary")
+
+	matches, err = matchingTests("arser.TestParseMarkdown*", testSet)
+	assert.NoError(t, err)
+	assert.Empty(t, matches, "globs do not match inside a path element")
+
+	matches, err = matchingTests("go:parser.TestParseM*", testSet)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"go:Alge/aligned/internal/parser.TestParseMarkdown", "go:Alge/aligned/internal/parser.TestParseMarkdownTables"}, matches)
+
+	matches, err = matchingTests("re:Parse(Gherkin|MarkdownTables)$", testSet)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Alge/aligned/internal/parser.TestParseGherkin", "Alge/aligned/internal/parser.TestParseMarkdownTables"}, matches)
+
+	pytestSet := map[string]bool{"tests/test_api.py::test_login": true, "tests/test_api.py::test_logout": true}
+	matches, err = matchingTests("test_log*", pytestSet)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"tests/test_api.py::test_login", "tests/test_api.py::test_logout"}, matches, "globs match from a :: boundary")
+
+	_, err = matchingTests("re:Parse(", testSet)
+	assert.Error(t, err)
+
+	assert.True(t, isTestPatternReference("parser.TestParse*"))
+	assert.True(t, isTestPatternReference("re:TestParse"))
+	assert.True(t, isTestPatternReference("parser.TestParse/*"))
+	assert.False(t, isTestPatternReference("tests/test_api.py::test_login[admin]"))
+
+	parent, ok := subtestPatternParent("parser.TestParse/*")
+	assert.True(t, ok)
+	assert.Equal(t, "parser.TestParse", parent)
+	_, ok = subtestPatternParent("parser.TestParse*")
+	assert.False(t, ok)
+}
+
+func TestCheckTestPatterns(t *testing.T) {
+	specContent := "# Parser\n\n" +
+		"## Parse markdown\n" +
+		"**Test:** `testproject.TestParseMarkdown*`\n\n" +
+		"## Parse gherkin\n" +
+		"**Test:** `re:TestParse(Gherkin|Feature)`\n\n" +
+		"## Parse every format\n" +
+		"**Test:** `testproject.TestParse*`\n" +
+		"**Min matches:** 5\n\n" +
+		"## Parse tables\n" +
+		"**Test:** `testproject.TestTable*`\n\n" +
+		"## Broken pattern\n" +
+		"**Test:** `re:TestParse(`\n\n" +
+		"## Parse every gherkin case\n" +
+		"**Test:** `testproject.TestParseGherkin/*`\n\n" +
+		"## Count gherkin cases\n" +
+		"**Test:** `testproject.TestParseGherkin/*`\n" +
+		"**Min matches:** 5\n"
+
+	testFiles := map[string]string{
+		"parser_test.go": `package parser
+import "testing"
+func TestParseMarkdownHeadings(t *testing.T) {}
+func TestParseMarkdownLists(t *testing.T) {}
+func TestParseGherkin(t *testing.T) {}
+`,
+	}
+
+	tempDir, specPath := setupTestProject(t, testFiles, specContent)
+
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+	os.Chdir(tempDir)
+
+	var stdout, stderr bytes.Buffer
+	exitCode := run([]string{"check", "-v", specPath}, &stdout, &stderr)
+
+	assert.Equal(t, 1, exitCode, stderr.String())
+	output := stdout.String()
+	assert.Contains(t, output, "(testproject.TestParseMarkdown*: 2 matching tests)")
+	assert.Contains(t, output, colorGray+"testproject.TestParseMarkdownHeadings"+colorReset, "matched tests are listed")
+	assert.Contains(t, output, colorGray+"testproject.TestParseMarkdownLists"+colorReset)
+	assert.Contains(t, output, "(re:TestParse(Gherkin|Feature): 1 matching tests)")
+	assert.Contains(t, output, "spec.md:10: "+colorRed+"Only 3 tests match testproject.TestParse*, expected at least 5")
+	assert.Contains(t, output, "spec.md:14: "+colorRed+"No tests match testproject.TestTable*")
+	assert.Contains(t, output, "spec.md:17: "+colorRed+"Invalid test pattern re:TestParse(")
+	assert.Contains(t, output, "(testproject.TestParseGherkin/*: parent found, subtest unverified)")
+	assert.Contains(t, output, "spec.md:23: "+colorRed+"Cannot count 5 matches of testproject.TestParseGherkin/*, go test -list does not report subtests")
+	assert.Contains(t, output, "4 test references not found")
+}
+
+func TestCheckTestPrefixes(t *testing.T) {
+	specContent := "# API\n" +
+		"**Test prefix:** `testproject.`\n\n" +
+		"## Login\n" +
+		"**Test:** `TestLogin`\n\n" +
+		"## Logout\n" +
+		"**Test:** `TestLogout`\n"
+
+	testFiles := map[string]string{
+		"api_test.go": `package api
+import "testing"
+func TestLogin(t *testing.T) {}
+`,
+	}
+
+	tempDir, specPath := setupTestProject(t, testFiles, specContent)
+
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+	os.Chdir(tempDir)
+
+	var stdout, stderr bytes.Buffer
+	exitCode := run([]string{"check", "-v", specPath}, &stdout, &stderr)
+
+	assert.Equal(t, 1, exitCode, stderr.String())
+	output := stdout.String()
+	assert.Contains(t, output, "(testproject.TestLogin)")
+	assert.Contains(t, output, "spec.md:8: "+colorRed+"Test not found: testproject.TestLogout")
+}
+
+func TestCheckDerivedTestsUseTestPrefixes(t *testing.T) {
+	specContent := "# Framework\n\n" +
+		"## Connector [INTERFACE]\n" +
+		"**Test pattern:** `Test{Section}`\n\n" +
+		"### Parse A\n\n" +
+		"### Parse B\n\n" +
+		"## Impl [IMPLEMENTS: Connector]\n" +
+		"**Test prefix:** `testproject.`\n\n" +
+		"### Parse A\n\n" +
+		"### Parse B\n"
+
+	testFiles := map[string]string{
+		"impl_test.go": `package impl
+import "testing"
+func TestParseA(t *testing.T) {}
+`,
+	}
+
+	tempDir, specPath := setupTestProject(t, testFiles, specContent)
+
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+	os.Chdir(tempDir)
+
+	var stdout, stderr bytes.Buffer
+	exitCode := run([]string{"check", "-v", specPath}, &stdout, &stderr)
+
+	assert.Equal(t, 1, exitCode, stderr.String())
+	output := stdout.String()
+	assert.Contains(t, output, "(testproject.TestParseA)", "derived test names get the implementation's test prefix")
+	assert.Contains(t, output, "Test not found: testproject.TestParseB")
+}
+
+func TestCheckSharedInterfaceIncludedTwice(t *testing.T) {
+	testFiles := map[string]string{
+		"connector_test.go": `package connector
+import "testing"
+func TestDiscover(t *testing.T) {}
+`,
+	}
+
+	tempDir, _ := setupTestProject(t, testFiles, "")
+
+	specDir := filepath.Join(tempDir, "specs")
+	err := os.MkdirAll(filepath.Join(specDir, "shared"), 0755)
+	assert.NoError(t, err)
+	err = os.WriteFile(filepath.Join(specDir, "shared", "conn.md"), []byte("# Connector [INTERFACE]\n\n## Discover tests\n"), 0644)
+	assert.NoError(t, err)
+	specContent := "# Services\n\n" +
+		"## Billing\n" +
+		"<!-- align:include shared/conn.md -->\n\n" +
+		"## Shipping\n" +
+		"<!-- align:include shared/conn.md -->\n\n" +
+		"## Go Connector [IMPLEMENTS: shared/Connector]\n\n" +
+		"### Discover tests\n" +
+		"**Test:** `testproject.TestDiscover`\n"
+	err = os.WriteFile(filepath.Join(specDir, "services.md"), []byte(specContent), 0644)
+	assert.NoError(t, err)
+
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+	os.Chdir(tempDir)
+
+	var stdout, stderr bytes.Buffer
+	exitCode := run([]string{"check", specDir}, &stdout, &stderr)
+
+	assert.Equal(t, 0, exitCode, stdout.String()+stderr.String())
+	output := stdout.String()
+	assert.NotContains(t, output, "already defined", "an interface included twice is declared once")
+	assert.NotContains(t, output, "ambiguous")
+}
+
+func TestCheckAmbiguousReferences(t *testing.T) {
+	specContent := "# API\n\n" +
+		"## Login\n" +
+		"**Test:** `login.spec.js > login::works`\n\n" +
+		"## Login from Vitest\n" +
+		"**Test:** `vitest:login.spec.js > login::works`\n"
+
+	// Both connectors report the same name
+	testFiles := map[string]string{
+		"fake-pytest": "#!/bin/sh\necho 'login.spec.js > login::works'\n",
+		"fake-vitest": "#!/bin/sh\necho '[{\"name\": \"login::works\", \"file\": \"login.spec.js\"}]'\n",
+	}
+
+	tempDir, specPath := setupTestProject(t, testFiles, specContent)
+	assert.NoError(t, os.Chmod(filepath.Join(tempDir, "fake-pytest"), 0755))
+	assert.NoError(t, os.Chmod(filepath.Join(tempDir, "fake-vitest"), 0755))
+
+	configContent := `connectors:
+  - type: vitest
+    executable: ./fake-vitest
+    path: .
+  - type: pytest
+    executable: ./fake-pytest
+    path: .
+`
+	err := os.WriteFile(filepath.Join(tempDir, ".align.yml"), []byte(configContent), 0644)
+	assert.NoError(t, err)
+
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+	os.Chdir(tempDir)
+
+	var stdout, stderr bytes.Buffer
+	exitCode := run([]string{"check", specPath}, &stdout, &stderr)
+
+	assert.Equal(t, 1, exitCode, stderr.String())
+	output := stdout.String()
+	assert.Contains(t, output, colorRed+"✗"+colorReset+" "+colorBlue+"API", "the collapsed tree shows the ambiguous reference as a failure")
+	assert.Contains(t, output, colorRed+"✗"+colorReset+" "+colorBlue+"Login"+colorReset+" "+colorRed+"(Ambiguous test reference: login.spec.js > login::works)")
+	assert.Contains(t, output, colorGreen+"✓"+colorReset+" "+colorBlue+"Login from Vitest", "qualified references are not ambiguous")
+	assert.Contains(t, output, "spec.md:4: "+colorRed+"Ambiguous test reference login.spec.js > login::works (found by vitest, pytest), qualify it as vitest:login.spec.js > login::works")
+	assert.Contains(t, output, "1 ambiguous test references")
+	assert.NotContains(t, output, "test references not found", "an ambiguous reference is not reported as missing")
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Alge/aligned/internal/spec"
+)
+
+// sectionFilter selects which leaf sections the check and show commands operate on
+type sectionFilter struct {
+	owners      []string
+	statuses    []string
+	tags        []string
+	excludeTags []string
+}
+
+// parseArg recognizes a filter option at args[i], in either "--owner value" or
+// "--owner=value" form. It returns how many arguments were consumed (0 if
+// args[i] is not a filter option).
+func (f *sectionFilter) parseArg(args []string, i int) (int, error) {
+	options := map[string]*[]string{
+		"--owner":       &f.owners,
+		"--status":      &f.statuses,
+		"--tag":         &f.tags,
+		"--exclude-tag": &f.excludeTags,
+	}
+
+	arg := args[i]
+	for name, values := range options {
+		if value, ok := strings.CutPrefix(arg, name+"="); ok {
+			*values = append(*values, value)
+			return 1, nil
+		}
+		if arg == name {
+			if i+1 >= len(args) || strings.HasPrefix(args[i+1], "-") {
+				return 0, fmt.Errorf("%s requires a value", name)
+			}
+			*values = append(*values, args[i+1])
+			return 2, nil
+		}
+	}
+	return 0, nil
+}
+
+// active returns true if any filter option was given
+func (f *sectionFilter) active() bool {
+	return len(f.owners) > 0 || len(f.statuses) > 0 || len(f.tags) > 0 || len(f.excludeTags) > 0
+}
+
+// matches returns true if a leaf section passes every filter option
+func (f *sectionFilter) matches(section *spec.Section) bool {
+	metadata := section.Metadata
+	if metadata == nil {
+		metadata = &spec.Metadata{}
+	}
+
+	if len(f.owners) > 0 && !containsFold(f.owners, metadata.Owner) {
+		return false
+	}
+	if len(f.statuses) > 0 && !containsFold(f.statuses, metadata.Status) {
+		return false
+	}
+
+	// A section needs at least one of the requested tags and none of the excluded ones
+	if len(f.tags) > 0 && !hasAnyTag(section, f.tags) {
+		return false
+	}
+	if hasAnyTag(section, f.excludeTags) {
+		return false
+	}
+	return true
+}
+
+// hasAnyTag returns true if any of the tags applies to the section
+func hasAnyTag(section *spec.Section, tags []string) bool {
+	for _, tag := range tags {
+		if section.HasTag(tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// apply returns the part of the specification selected by the filter
+func (f *sectionFilter) apply(specification *spec.Specification) *spec.Specification {
+	if !f.active() {
+		return specification
+	}
+	return specification.Filter(f.matches)
+}
+
+// containsFold returns true if value case-insensitively equals one of values
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
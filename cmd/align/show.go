@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"strings"
 
+	"github.com/Alge/aligned/internal/config"
 	"github.com/Alge/aligned/internal/parser"
 	"github.com/Alge/aligned/internal/spec"
 )
@@ -23,15 +25,38 @@ const (
 
 func show(args []string, stdout, stderr io.Writer) int {
 	// Check arguments
-	if len(args) < 1 {
-		fmt.Fprintln(stderr, "Usage: align show <spec-file-or-directory>")
+	specPath := ""
+	var filter sectionFilter
+
+	for i := 0; i < len(args); i++ {
+		consumed, err := filter.parseArg(args, i)
+		if err != nil {
+			fmt.Fprintf(stderr, "Error: %v\n", err)
+			return 1
+		}
+		if consumed > 0 {
+			i += consumed - 1
+			continue
+		}
+		if specPath == "" && !strings.HasPrefix(args[i], "-") {
+			specPath = args[i]
+		}
+	}
+
+	if specPath == "" {
+		fmt.Fprintln(stderr, "Usage: align show [--tag <tag>] [--exclude-tag <tag>] [--owner <name>] [--status <status>] <spec-file-or-directory>")
 		return 1
 	}
 
-	specPath := args[0]
+	// The configuration is optional here, but decides which files a spec directory holds
+	options, err := loadDirectoryOptions()
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: Invalid configuration: %v\n", err)
+		return 1
+	}
 
 	// Load specification (file or directory)
-	specification, err := loadSpecificationForShow(specPath)
+	specification, err := loadSpecification(specPath, options)
 	if err != nil {
 		if os.IsNotExist(err) {
 			fmt.Fprintf(stderr, "Error: Spec path not found: %s\n", specPath)
@@ -42,35 +67,21 @@ func show(args []string, stdout, stderr io.Writer) int {
 	}
 
 	// Display the spec structure
-	printSpecification(specification, stdout)
+	printSpecification(filter.apply(specification), stdout)
 
 	return 0
 }
 
-func loadSpecificationForShow(path string) (*spec.Specification, error) {
-	info, err := os.Stat(path)
-	if err != nil {
-		return nil, err
+// loadDirectoryOptions reads the spec file selection from .align.yml, if there is one
+func loadDirectoryOptions() (parser.DirectoryOptions, error) {
+	cfg, err := config.LoadConfiguration(filepath.Join(".", ".align.yml"))
+	if os.IsNotExist(err) {
+		return parser.DirectoryOptions{}, nil
 	}
-
-	if !info.IsDir() {
-		// Single file
-		content, err := os.ReadFile(path)
-		if err != nil {
-			return nil, err
-		}
-
-		specification, err := parser.ParseMarkdown(string(content))
-		if err != nil {
-			return nil, err
-		}
-		specification.FilePath = path
-
-		return specification, nil
+	if err != nil {
+		return parser.DirectoryOptions{}, err
 	}
-
-	// Directory - use the ParseDirectory function
-	return parser.ParseDirectory(path)
+	return directoryOptions(cfg), nil
 }
 
 func printSpecification(specification *spec.Specification, stdout io.Writer) {
@@ -84,15 +95,58 @@ func printSection(section *spec.Section, indent int, stdout io.Writer) {
 	prefix := colorGray + strings.Repeat("· ", indent) + colorReset
 	
 	// Print section title (blue for headings)
-	fmt.Fprintf(stdout, "%s%s%s%s\n", prefix, colorBlue, section.Title, colorReset)
+	fmt.Fprintf(stdout, "%s%s%s\n", prefix, sectionLabel(section), colorReset)
 	
-	// If section has a test, show it (green)
-	if section.TestName != "" {
-		fmt.Fprintf(stdout, "%s  %sTest: %s%s%s\n",
+	// Show file metadata once, on the first section of each file
+	if metadata := section.Metadata; metadata != nil && (section.Parent == nil || section.Parent.Metadata != metadata) {
+		if details := formatMetadata(metadata); details != "" {
+			fmt.Fprintf(stdout, "%s  %s%s%s\n",
+				colorGray+strings.Repeat("· ", indent)+colorReset,
+				colorGray,
+				details,
+				colorReset)
+		}
+	}
+
+	// Show tags declared on this section
+	if len(section.Tags) > 0 {
+		fmt.Fprintf(stdout, "%s  %sTags: %s%s\n",
+			colorGray+strings.Repeat("· ", indent)+colorReset,
+			colorGray,
+			strings.Join(section.Tags, ", "),
+			colorReset)
+	}
+
+	// If section has tests, show them (green)
+	if section.HasTest() {
+		label := "Test"
+		if section.TestDerived {
+			label = "Test (from pattern)"
+		}
+		if len(section.Tests()) > 1 {
+			label = "Tests (all of)"
+			if section.MatchesAny() {
+				label = "Tests (any of)"
+			}
+		}
+		fmt.Fprintf(stdout, "%s  %s%s: %s%s%s\n",
 			colorGray+strings.Repeat("· ", indent)+colorReset,
 			colorGray,
+			label,
 			colorGreen,
-			section.TestName,
+			strings.Join(section.Tests(), ", "),
+			colorReset)
+	} else if section.Justification != "" {
+		fmt.Fprintf(stdout, "%s  %sJustification: %s%s\n",
+			colorGray+strings.Repeat("· ", indent)+colorReset,
+			colorGray,
+			section.Justification,
+			colorReset)
+	} else if section.Reason != "" {
+		fmt.Fprintf(stdout, "%s  %sReason: %s%s\n",
+			colorGray+strings.Repeat("· ", indent)+colorReset,
+			colorGray,
+			section.Reason,
 			colorReset)
 	} else if section.RequiresTest() {
 		// Leaf section without test - show warning (but not for interface sections)
@@ -106,4 +160,28 @@ func printSection(section *spec.Section, indent int, stdout io.Writer) {
 	for _, child := range section.Children {
 		printSection(child, indent+1, stdout)
 	}
-}
\ No newline at end of file
+}
+
+// sectionLabel returns the colored heading text for a section,
+// prefixed with its requirement ID when it has one
+func sectionLabel(section *spec.Section) string {
+	if section.ID != "" {
+		return fmt.Sprintf("%s%s %s%s", colorGray, section.ID, colorBlue, section.Title)
+	}
+	return colorBlue + section.Title
+}
+
+// formatMetadata returns a one-line summary of the owner, status and tags from front matter
+func formatMetadata(metadata *spec.Metadata) string {
+	var parts []string
+	if metadata.Owner != "" {
+		parts = append(parts, "Owner: "+metadata.Owner)
+	}
+	if metadata.Status != "" {
+		parts = append(parts, "Status: "+metadata.Status)
+	}
+	if len(metadata.Tags) > 0 {
+		parts = append(parts, "Tags: "+strings.Join(metadata.Tags, ", "))
+	}
+	return strings.Join(parts, " · ")
+}
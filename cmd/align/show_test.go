@@ -310,3 +310,140 @@ Sends a request to the server
 		}
 	}
 }
+
+func TestShowDisplaysRequirementIDs(t *testing.T) {
+	tempDir := t.TempDir()
+	specContent := "# Parser\n\n## Parse headings {#PARSER-012}\n**Test:** `TestParse`\n"
+	specPath := filepath.Join(tempDir, "test.md")
+	err := os.WriteFile(specPath, []byte(specContent), 0644)
+	assert.NoError(t, err)
+
+	var stdout, stderr bytes.Buffer
+	exitCode := run([]string{"show", specPath}, &stdout, &stderr)
+
+	assert.Equal(t, 0, exitCode)
+	output := stdout.String()
+	assert.Contains(t, output, "PARSER-012", "should display the requirement ID")
+	assert.Contains(t, output, "Parse headings")
+	assert.NotContains(t, output, "{#PARSER-012}", "should not display the raw ID marker")
+}
+
+func TestShowDisplaysFrontMatter(t *testing.T) {
+	tempDir := t.TempDir()
+	specContent := `---
+owner: platform-team
+status: approved
+tags: [security]
+title: Authentication
+---
+# Auth
+
+## Login
+**Test:** ` + "`TestLogin`" + `
+`
+	specPath := filepath.Join(tempDir, "auth.md")
+	err := os.WriteFile(specPath, []byte(specContent), 0644)
+	assert.NoError(t, err)
+
+	var stdout, stderr bytes.Buffer
+	exitCode := run([]string{"show", specPath}, &stdout, &stderr)
+
+	assert.Equal(t, 0, exitCode)
+	output := stdout.String()
+	assert.Contains(t, output, "Authentication", "should use the display title")
+	assert.Contains(t, output, "Owner: platform-team")
+	assert.Contains(t, output, "Status: approved")
+	assert.Contains(t, output, "Tags: security")
+	assert.NotContains(t, output, "---")
+}
+
+func TestShowFiltersByMetadata(t *testing.T) {
+	tempDir := t.TempDir()
+	err := os.WriteFile(filepath.Join(tempDir, "auth.md"),
+		[]byte("---\nowner: security-team\n---\n# Auth\n\n## Login\n**Test:** `TestLogin`\n"), 0644)
+	assert.NoError(t, err)
+	err = os.WriteFile(filepath.Join(tempDir, "billing.md"),
+		[]byte("---\nowner: billing-team\nstatus: draft\n---\n# Billing\n\n## Invoice\n**Test:** `TestInvoice`\n"), 0644)
+	assert.NoError(t, err)
+
+	t.Run("owner", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		exitCode := run([]string{"show", "--owner", "security-team", tempDir}, &stdout, &stderr)
+
+		assert.Equal(t, 0, exitCode)
+		assert.Contains(t, stdout.String(), "Login")
+		assert.NotContains(t, stdout.String(), "Invoice")
+	})
+
+	t.Run("status", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		exitCode := run([]string{"show", "--status=draft", tempDir}, &stdout, &stderr)
+
+		assert.Equal(t, 0, exitCode)
+		assert.Contains(t, stdout.String(), "Invoice")
+		assert.NotContains(t, stdout.String(), "Login")
+	})
+
+	t.Run("missing value", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		exitCode := run([]string{"show", tempDir, "--owner"}, &stdout, &stderr)
+
+		assert.Equal(t, 1, exitCode)
+		assert.Contains(t, stderr.String(), "--owner requires a value")
+	})
+}
+
+func TestShowFiltersByTag(t *testing.T) {
+	tempDir := t.TempDir()
+	specContent := `# Spec
+
+## Login
+**Tags:** security, api
+**Test:** ` + "`TestLogin`" + `
+
+## Export
+**Tags:** reporting
+**Test:** ` + "`TestExport`" + `
+`
+	specPath := filepath.Join(tempDir, "test.md")
+	err := os.WriteFile(specPath, []byte(specContent), 0644)
+	assert.NoError(t, err)
+
+	var stdout, stderr bytes.Buffer
+	exitCode := run([]string{"show", "--tag", "security", specPath}, &stdout, &stderr)
+
+	assert.Equal(t, 0, exitCode)
+	output := stdout.String()
+	assert.Contains(t, output, "Login")
+	assert.Contains(t, output, "Tags: security, api", "should display section tags")
+	assert.NotContains(t, output, "Export")
+
+	stdout.Reset()
+	exitCode = run([]string{"show", "--exclude-tag", "security", specPath}, &stdout, &stderr)
+
+	assert.Equal(t, 0, exitCode)
+	assert.Contains(t, stdout.String(), "Export")
+	assert.NotContains(t, stdout.String(), "Login")
+}
+
+func TestShowRequirementTables(t *testing.T) {
+	tempDir := t.TempDir()
+	specContent := "# Login\n\n## Behavior matrix\n" +
+		"| Requirement | Test |\n|---|---|\n" +
+		"| Rejects empty password | `TestRejectEmpty` |\n" +
+		"| Shows a strength hint | |\n"
+	specPath := filepath.Join(tempDir, "test.md")
+	err := os.WriteFile(specPath, []byte(specContent), 0644)
+	assert.NoError(t, err)
+
+	var stdout, stderr bytes.Buffer
+	exitCode := run([]string{"show", specPath}, &stdout, &stderr)
+
+	assert.Equal(t, 0, exitCode)
+	output := stdout.String()
+	assert.Contains(t, output, "Rejects empty password", "each row is shown as a requirement")
+	assert.Contains(t, output, "TestRejectEmpty")
+	assert.Contains(t, output, "Shows a strength hint")
+	assert.Contains(t, output, "Missing test reference", "rows without a test are flagged")
+	assert.NotContains(t, output, "| Requirement |")
+}
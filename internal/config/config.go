@@ -9,6 +9,15 @@ import (
 
 type Configuration struct {
 	Connectors []ConnectorConfig `yaml:"connectors"`
+	// Report implementation sections that are not declared by their interface
+	StrictInterfaces bool `yaml:"strict_interfaces,omitempty"`
+	// Which files are loaded when the specification is a directory
+	Spec SpecConfig `yaml:"spec,omitempty"`
+}
+
+type SpecConfig struct {
+	Extensions []string `yaml:"extensions,omitempty"`
+	Exclude    []string `yaml:"exclude,omitempty"`
 }
 
 type ConnectorConfig struct {
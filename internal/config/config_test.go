@@ -141,4 +141,45 @@ func TestLoadConfiguration(t *testing.T) {
 			})
 		}
 	})
-}
\ No newline at end of file
+}
+
+func TestLoadStrictInterfacesOption(t *testing.T) {
+	tempDir := t.TempDir()
+
+	configContent := `connectors:
+  - type: go
+    path: ./
+strict_interfaces: true
+`
+	configPath := filepath.Join(tempDir, ".align.yml")
+	err := os.WriteFile(configPath, []byte(configContent), 0644)
+	assert.NoError(t, err)
+
+	config, err := LoadConfiguration(configPath)
+
+	assert.NoError(t, err)
+	assert.True(t, config.StrictInterfaces)
+}
+
+func TestLoadSpecOptions(t *testing.T) {
+	tempDir := t.TempDir()
+
+	configContent := `connectors:
+  - type: go
+    path: ./
+spec:
+  extensions: [.md, .mdx]
+  exclude:
+    - node_modules/
+    - "**/CHANGELOG.md"
+`
+	configPath := filepath.Join(tempDir, ".align.yml")
+	err := os.WriteFile(configPath, []byte(configContent), 0644)
+	assert.NoError(t, err)
+
+	config, err := LoadConfiguration(configPath)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{".md", ".mdx"}, config.Spec.Extensions)
+	assert.Equal(t, []string{"node_modules/", "**/CHANGELOG.md"}, config.Spec.Exclude)
+}
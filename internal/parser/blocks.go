@@ -0,0 +1,227 @@
+package parser
+
+import (
+	"regexp"
+	"strings"
+)
+
+// markdownBlock is a single line of markdown classified by scanBlocks.
+// Headings carry their level and title; every other line is content.
+type markdownBlock struct {
+	Heading bool
+	Level   int
+	Title   string
+	Text    string // Raw line (content lines only)
+	Visible bool   // False for lines inside code blocks and HTML comments
+	Line    int    // 1-based line number of the line (or of a setext heading's first line)
+	Include string // Path of an <!-- align:include path --> directive on this line
+}
+
+var (
+	// ATX heading: up to 3 spaces, 1-6 #, then whitespace or end of line
+	atxHeadingPattern = regexp.MustCompile(`^ {0,3}(#{1,6})(?:[ \t]+(.*?))?[ \t]*$`)
+	// Optional closing sequence of an ATX heading, which must follow whitespace
+	atxClosingPattern = regexp.MustCompile(`[ \t]+#+$`)
+	// Setext underline: === for level 1, --- for level 2
+	setextUnderlinePattern = regexp.MustCompile(`^ {0,3}(=+|-+)[ \t]*$`)
+	// Opening code fence with an optional info string
+	fenceOpenPattern = regexp.MustCompile("^ {0,3}(`{3,}|~{3,})(.*)$")
+	// Lines that start a container block, whose text cannot become a setext heading
+	containerStartPattern   = regexp.MustCompile(`^ {0,3}(?:[-*+]|\d{1,9}[.)]|>)(?:[ \t]|$)`)
+	htmlCommentStartPattern = regexp.MustCompile(`^ {0,3}<!--`)
+	thematicBreakPattern    = regexp.MustCompile(`^ {0,3}(?:(?:-[ \t]*){3,}|(?:\*[ \t]*){3,}|(?:_[ \t]*){3,})$`)
+)
+
+// scanBlocks splits markdown into headings and content lines following the
+// CommonMark rules that matter for finding headings: ATX and setext headings,
+// fenced and indented code blocks, and HTML comments.
+func scanBlocks(content string) []markdownBlock {
+	var blocks []markdownBlock
+
+	// Lines of the paragraph being read, held back because a setext
+	// underline turns the whole paragraph into a heading
+	var paragraph []markdownBlock
+	// True while lines continue a list item or block quote rather than a paragraph
+	inContainer := false
+
+	var fence string   // Opening fence of the current fenced code block
+	inComment := false // Inside an HTML comment
+	inIndentedCode := false
+
+	flushParagraph := func() {
+		blocks = append(blocks, paragraph...)
+		paragraph = nil
+	}
+	addContent := func(text string, visible bool, line int) {
+		blocks = append(blocks, markdownBlock{Text: text, Visible: visible, Line: line})
+	}
+
+	lines := strings.Split(content, "\n")
+	// A trailing newline does not start another line
+	if len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	for i, raw := range lines {
+		line := strings.TrimRight(raw, "\r")
+		lineNumber := i + 1
+
+		// Inside a fenced code block, only a matching closing fence ends it
+		if fence != "" {
+			if isClosingFence(line, fence) {
+				fence = ""
+			}
+			addContent(line, false, lineNumber)
+			continue
+		}
+
+		// Inside an HTML comment, everything up to --> is hidden
+		if inComment {
+			if strings.Contains(line, "-->") {
+				inComment = false
+			}
+			addContent(line, false, lineNumber)
+			continue
+		}
+
+		blank := strings.TrimSpace(line) == ""
+
+		// Indented code continues until a non-blank line with less indentation
+		if inIndentedCode {
+			if blank || indentation(line) >= 4 {
+				addContent(line, false, lineNumber)
+				continue
+			}
+			inIndentedCode = false
+		}
+
+		if blank {
+			flushParagraph()
+			inContainer = false
+			addContent(line, true, lineNumber)
+			continue
+		}
+
+		// Indented code cannot interrupt a paragraph or continue a container
+		if indentation(line) >= 4 && len(paragraph) == 0 && !inContainer {
+			inIndentedCode = true
+			addContent(line, false, lineNumber)
+			continue
+		}
+
+		if open := fenceOpenPattern.FindStringSubmatch(line); open != nil && !(open[1][0] == '`' && strings.Contains(open[2], "`")) {
+			flushParagraph()
+			inContainer = false
+			fence = open[1]
+			addContent(line, false, lineNumber)
+			continue
+		}
+
+		if htmlCommentStartPattern.MatchString(line) {
+			flushParagraph()
+			inContainer = false
+			start := strings.Index(line, "<!--")
+			inComment = !strings.Contains(line[start+4:], "-->")
+			block := markdownBlock{Text: line, Line: lineNumber}
+			if directive := includeDirectivePattern.FindStringSubmatch(line); directive != nil {
+				block.Include = directive[1]
+			}
+			blocks = append(blocks, block)
+			continue
+		}
+
+		if matches := atxHeadingPattern.FindStringSubmatch(line); matches != nil {
+			flushParagraph()
+			inContainer = false
+			blocks = append(blocks, markdownBlock{
+				Heading: true,
+				Level:   len(matches[1]),
+				Title:   stripClosingSequence(matches[2]),
+				Line:    lineNumber,
+			})
+			continue
+		}
+
+		if len(paragraph) > 0 && !inContainer {
+			if underline := setextUnderlinePattern.FindStringSubmatch(line); underline != nil {
+				level := 2
+				if underline[1][0] == '=' {
+					level = 1
+				}
+				var parts []string
+				for _, p := range paragraph {
+					parts = append(parts, strings.TrimSpace(p.Text))
+				}
+				blocks = append(blocks, markdownBlock{
+					Heading: true,
+					Level:   level,
+					Title:   strings.Join(parts, " "),
+					Line:    paragraph[0].Line,
+				})
+				paragraph = nil
+				continue
+			}
+		}
+
+		if thematicBreakPattern.MatchString(line) {
+			flushParagraph()
+			inContainer = false
+			addContent(line, true, lineNumber)
+			continue
+		}
+
+		if containerStartPattern.MatchString(line) {
+			flushParagraph()
+			inContainer = true
+			addContent(line, true, lineNumber)
+			continue
+		}
+
+		if inContainer {
+			// Lazy continuation of a list item or block quote
+			addContent(line, true, lineNumber)
+			continue
+		}
+
+		paragraph = append(paragraph, markdownBlock{Text: line, Visible: true, Line: lineNumber})
+	}
+
+	flushParagraph()
+	return blocks
+}
+
+// isClosingFence returns true if line closes a code block opened with fence
+func isClosingFence(line string, fence string) bool {
+	if indentation(line) > 3 {
+		return false
+	}
+	trimmed := strings.TrimSpace(line)
+	if len(trimmed) < len(fence) {
+		return false
+	}
+	return strings.Trim(trimmed, fence[:1]) == ""
+}
+
+// stripClosingSequence removes the optional trailing #s from an ATX heading
+func stripClosingSequence(title string) string {
+	if strings.Trim(title, "#") == "" {
+		return ""
+	}
+	return strings.TrimSpace(atxClosingPattern.ReplaceAllString(title, ""))
+}
+
+// indentation returns the width of leading whitespace, counting tabs as 4 columns
+func indentation(line string) int {
+	width := 0
+	for _, r := range line {
+		switch r {
+		case ' ':
+			width++
+		case '\t':
+			width += 4 - width%4
+		default:
+			return width
+		}
+	}
+	return width
+}
@@ -0,0 +1,102 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// headingTitles returns the titles of all sections in parse order
+func headingTitles(t *testing.T, content string) []string {
+	t.Helper()
+
+	var titles []string
+	for _, block := range scanBlocks(content) {
+		if block.Heading {
+			titles = append(titles, block.Title)
+		}
+	}
+	return titles
+}
+
+func TestScanBlocksHeadingDetection(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{
+			name:     "atx headings",
+			input:    "# One\n\n## Two\n\n###### Six\n",
+			expected: []string{"One", "Two", "Six"},
+		},
+		{
+			name:     "closing sequence is stripped",
+			input:    "## Two ##\n### Issue #42\n## C# support ###\n",
+			expected: []string{"Two", "Issue #42", "C# support"},
+		},
+		{
+			name:     "hash without space is not a heading",
+			input:    "#hashtag\n####### seven\n# Real\n",
+			expected: []string{"Real"},
+		},
+		{
+			name:     "up to three spaces of indentation",
+			input:    "   ## Indented\n    ## Code\n",
+			expected: []string{"Indented"},
+		},
+		{
+			name:     "shell comments in fenced code blocks",
+			input:    "# Install\n\n```bash\n# install the tool\ngo install ./...\n```\n\n~~~\n# also code\n```\n# still code\n~~~\n## After\n",
+			expected: []string{"Install", "After"},
+		},
+		{
+			name:     "indented code block",
+			input:    "# Usage\n\n    # not a heading\n    run it\n\n## After\n",
+			expected: []string{"Usage", "After"},
+		},
+		{
+			name:     "html comments",
+			input:    "# Visible\n<!--\n# Hidden\n-->\n<!-- # also hidden -->\n## After\n",
+			expected: []string{"Visible", "After"},
+		},
+		{
+			name:     "setext headings",
+			input:    "Title\n=====\n\nSubtitle over\ntwo lines\n---\n\nText\n",
+			expected: []string{"Title", "Subtitle over two lines"},
+		},
+		{
+			name:     "thematic break after blank line is not a heading",
+			input:    "# Title\n\nParagraph.\n\n---\n\nMore.\n",
+			expected: []string{"Title"},
+		},
+		{
+			name:     "list item followed by dashes is not a setext heading",
+			input:    "# Title\n\n- item\n---\n",
+			expected: []string{"Title"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, headingTitles(t, tt.input))
+		})
+	}
+}
+
+func TestScanBlocksLineNumbers(t *testing.T) {
+	blocks := scanBlocks("# One\n\nSetext\n------\n```\n# code\n```\n## Two\n")
+
+	var headings []markdownBlock
+	for _, block := range blocks {
+		if block.Heading {
+			headings = append(headings, block)
+		}
+	}
+
+	assert.Len(t, headings, 3)
+	assert.Equal(t, 1, headings[0].Line)
+	assert.Equal(t, 3, headings[1].Line, "setext headings start at their text line")
+	assert.Equal(t, 2, headings[1].Level)
+	assert.Equal(t, 8, headings[2].Line)
+}
@@ -9,10 +9,25 @@ import (
 )
 
 // ParseDirectory parses all .md files in a directory recursively and builds
-// a unified specification tree based on the directory structure
+// a unified specification tree based on the directory structure.
+// Files included by another file only appear where they are included.
 func ParseDirectory(rootPath string) (*spec.Specification, error) {
+	return ParseDirectoryWithOptions(rootPath, DirectoryOptions{})
+}
+
+// ParseDirectoryWithOptions works like ParseDirectory, loading the spec files
+// selected by options and skipping paths matched by .alignignore files
+func ParseDirectoryWithOptions(rootPath string, options DirectoryOptions) (*spec.Specification, error) {
+	files, err := newSpecFiles(rootPath, options)
+	if err != nil {
+		return nil, err
+	}
+	if err := files.collectIncludes(rootPath); err != nil {
+		return nil, err
+	}
+	
 	// Build the tree structure from filesystem
-	rootSection, err := buildDirectoryTree(rootPath, rootPath, -1) // Start at -1 so root files are at level 0
+	rootSection, err := buildDirectoryTree(rootPath, rootPath, -1, files) // Start at -1 so root files are at level 0
 	if err != nil {
 		return nil, err
 	}
@@ -20,6 +35,8 @@ func ParseDirectory(rootPath string) (*spec.Specification, error) {
 	// The root section is just a container - return its children as the root sections
 	if rootSection != nil && rootSection.Title == "" {
 		return &spec.Specification{
+			FilePath: rootPath,
+			RootDir:  rootPath,
 			Sections: rootSection.Children,
 		}, nil
 	}
@@ -27,17 +44,25 @@ func ParseDirectory(rootPath string) (*spec.Specification, error) {
 	// If we have content at root level, include it
 	if rootSection != nil {
 		return &spec.Specification{
+			FilePath: rootPath,
+			RootDir:  rootPath,
 			Sections: []*spec.Section{rootSection},
 		}, nil
 	}
 	
 	return &spec.Specification{
+		FilePath: rootPath,
+		RootDir:  rootPath,
 		Sections: []*spec.Section{},
 	}, nil
 }
 
 // buildDirectoryTree recursively builds a section tree from a directory
-func buildDirectoryTree(dirPath string, rootPath string, level int) (*spec.Section, error) {
+func buildDirectoryTree(dirPath string, rootPath string, level int, files *specFiles) (*spec.Section, error) {
+	if err := files.enter(dirPath); err != nil {
+		return nil, err
+	}
+	
 	// Read directory contents
 	entries, err := os.ReadDir(dirPath)
 	if err != nil {
@@ -47,19 +72,27 @@ func buildDirectoryTree(dirPath string, rootPath string, level int) (*spec.Secti
 	// Separate files and directories
 	var mdFiles []os.DirEntry
 	var subDirs []os.DirEntry
-	var hasDirnameMd bool
+	var dirnameMdFile string
 	
 	dirName := filepath.Base(dirPath)
-	dirnameMdFile := dirName + ".md"
+	dirTitle, _ := stripNumericPrefix(dirName)
 	
 	for _, entry := range entries {
+		path := filepath.Join(dirPath, entry.Name())
+		if files.isIgnored(path, entry.IsDir()) {
+			continue
+		}
 		if entry.IsDir() {
 			subDirs = append(subDirs, entry)
-		} else if strings.HasSuffix(entry.Name(), ".md") {
-			mdFiles = append(mdFiles, entry)
-			if entry.Name() == dirnameMdFile {
-				hasDirnameMd = true
+		} else if files.isSpecFile(entry.Name()) {
+			if files.isIncluded(path) {
+				continue
+			}
+			if name, _ := stripNumericPrefix(entry.Name()); name == dirTitle+files.extension(entry.Name()) && dirnameMdFile == "" {
+				dirnameMdFile = entry.Name()
+				continue
 			}
+			mdFiles = append(mdFiles, entry)
 		}
 	}
 	
@@ -67,13 +100,8 @@ func buildDirectoryTree(dirPath string, rootPath string, level int) (*spec.Secti
 	var childSections []*spec.Section
 	
 	// Process dirname.md if it exists
-	if hasDirnameMd {
-		content, err := os.ReadFile(filepath.Join(dirPath, dirnameMdFile))
-		if err != nil {
-			return nil, err
-		}
-		
-		parsed, err := ParseMarkdown(string(content))
+	if dirnameMdFile != "" {
+		parsed, err := ParseFile(filepath.Join(dirPath, dirnameMdFile))
 		if err != nil {
 			return nil, err
 		}
@@ -98,18 +126,11 @@ func buildDirectoryTree(dirPath string, rootPath string, level int) (*spec.Secti
 		}
 	}
 	
+	var ordered []directoryEntry
+	
 	// Process other .md files
 	for _, file := range mdFiles {
-		if hasDirnameMd && file.Name() == dirnameMdFile {
-			continue // Already processed
-		}
-		
-		content, err := os.ReadFile(filepath.Join(dirPath, file.Name()))
-		if err != nil {
-			return nil, err
-		}
-		
-		parsed, err := ParseMarkdown(string(content))
+		parsed, err := ParseFile(filepath.Join(dirPath, file.Name()))
 		if err != nil {
 			return nil, err
 		}
@@ -126,21 +147,42 @@ func buildDirectoryTree(dirPath string, rootPath string, level int) (*spec.Secti
 			} else {
 				adjustSectionLevels(section, level+2)
 			}
-			childSections = append(childSections, section)
 		}
+		
+		_, order := stripNumericPrefix(file.Name())
+		if parsed.Metadata != nil && parsed.Metadata.Order != nil {
+			order = parsed.Metadata.Order
+		}
+		ordered = append(ordered, directoryEntry{name: file.Name(), order: order, sections: parsed.Sections})
 	}
 	
 	// Process subdirectories
 	for _, subDir := range subDirs {
-		subSection, err := buildDirectoryTree(filepath.Join(dirPath, subDir.Name()), rootPath, level+1)
+		subSection, err := buildDirectoryTree(filepath.Join(dirPath, subDir.Name()), rootPath, level+1, files)
 		if err != nil {
 			return nil, err
 		}
 		if subSection != nil {
-			childSections = append(childSections, subSection)
+			_, order := stripNumericPrefix(subDir.Name())
+			if subSection.Metadata != nil && subSection.Metadata.Order != nil {
+				order = subSection.Metadata.Order
+			}
+			ordered = append(ordered, directoryEntry{name: subDir.Name(), isDir: true, order: order, sections: []*spec.Section{subSection}})
 		}
 	}
 	
+	// Place files and subdirectories in their configured order
+	listed, err := readOrderFile(dirPath)
+	if err != nil {
+		return nil, err
+	}
+	if err := sortDirectoryEntries(dirPath, ordered, listed); err != nil {
+		return nil, err
+	}
+	for _, entry := range ordered {
+		childSections = append(childSections, entry.sections...)
+	}
+	
 	// If no parent section was created from dirname.md, create one from directory name
 	if parentSection == nil && len(childSections) > 0 {
 		// Don't create a section for the root directory
@@ -152,7 +194,7 @@ func buildDirectoryTree(dirPath string, rootPath string, level int) (*spec.Secti
 		}
 		
 		parentSection = &spec.Section{
-			Title:    ConvertSnakeCaseToTitleCase(dirName),
+			Title:    ConvertSnakeCaseToTitleCase(dirTitle),
 			Level:    level + 1,
 			Children: childSections,
 		}
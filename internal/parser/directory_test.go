@@ -290,4 +290,158 @@ func TestConvertSnakeCaseToTitleCase(t *testing.T) {
 			assert.Equal(t, tt.expected, result)
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestParseDirectoryRecordsSourceFiles(t *testing.T) {
+	tempDir := t.TempDir()
+
+	subDir := filepath.Join(tempDir, "cli")
+	err := os.MkdirAll(subDir, 0755)
+	assert.NoError(t, err)
+	err = os.WriteFile(filepath.Join(subDir, "check.md"), []byte("# Check\n\n## Exit code\n**Test:** `TestExit`\n"), 0644)
+	assert.NoError(t, err)
+
+	specification, err := ParseDirectory(tempDir)
+	assert.NoError(t, err)
+	assert.Equal(t, tempDir, specification.FilePath)
+	assert.Equal(t, tempDir, specification.RootDir)
+
+	leaves := specification.AllLeaves()
+	assert.Len(t, leaves, 1)
+	assert.Equal(t, filepath.Join(subDir, "check.md"), leaves[0].FilePath)
+	assert.Equal(t, 3, leaves[0].Line)
+	assert.Equal(t, 4, leaves[0].TestLine)
+}
+
+func TestParseDirectoryOrdering(t *testing.T) {
+	titles := func(sections []*spec.Section) []string {
+		var result []string
+		for _, section := range sections {
+			result = append(result, section.Title)
+		}
+		return result
+	}
+
+	t.Run("numeric prefixes order entries and are stripped from titles", func(t *testing.T) {
+		dir := t.TempDir()
+		writeSpecFiles(t, dir, map[string]string{
+			"01_overview.md":        "# Overview\n",
+			"02_parser/lexer.md":    "# Lexer\n",
+			"03_checking.md":        "# Checking\n",
+			"10_appendix/extras.md": "# Extras\n",
+			"glossary.md":           "# Glossary\n",
+		})
+
+		specification, err := ParseDirectory(dir)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Overview", "Parser", "Checking", "Appendix", "Glossary"}, titles(specification.Sections),
+			"numbered files and directories are interleaved before unnumbered entries")
+	})
+
+	t.Run("dirname.md may carry the numeric prefix of its directory", func(t *testing.T) {
+		dir := t.TempDir()
+		writeSpecFiles(t, dir, map[string]string{
+			"02_parser/parser.md": "# Parser Specification\n",
+			"02_parser/lexer.md":  "# Lexer\n",
+		})
+
+		specification, err := ParseDirectory(dir)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Parser Specification"}, titles(specification.Sections))
+		assert.Equal(t, []string{"Lexer"}, titles(specification.Sections[0].Children))
+	})
+
+	t.Run("_order file lists entries first", func(t *testing.T) {
+		dir := t.TempDir()
+		writeSpecFiles(t, dir, map[string]string{
+			"_order":         "# Read in this order\nsetup\n\nreference/\n",
+			"advanced.md":    "# Advanced\n",
+			"setup.md":       "# Setup\n",
+			"reference/a.md": "# API\n",
+			"01_intro.md":    "# Intro\n",
+		})
+
+		specification, err := ParseDirectory(dir)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Setup", "Reference", "Intro", "Advanced"}, titles(specification.Sections))
+	})
+
+	t.Run("_order file naming a missing entry is an error", func(t *testing.T) {
+		dir := t.TempDir()
+		writeSpecFiles(t, dir, map[string]string{
+			"_order":   "setup\nmissing\n",
+			"setup.md": "# Setup\n",
+		})
+
+		_, err := ParseDirectory(dir)
+		assert.EqualError(t, err, filepath.Join(dir, "_order")+": no file or directory named 'missing'")
+	})
+
+	t.Run("front-matter order overrides the name", func(t *testing.T) {
+		dir := t.TempDir()
+		writeSpecFiles(t, dir, map[string]string{
+			"alpha.md":         "# Alpha\n",
+			"beta.md":          "---\norder: 1\n---\n# Beta\n",
+			"gamma/gamma.md":   "---\norder: 2\n---\n# Gamma\n",
+			"gamma/details.md": "# Details\n",
+		})
+
+		specification, err := ParseDirectory(dir)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Beta", "Gamma", "Alpha"}, titles(specification.Sections))
+	})
+}
+
+func TestParseDirectoryAlignIgnore(t *testing.T) {
+	dir := t.TempDir()
+	writeSpecFiles(t, dir, map[string]string{
+		".alignignore":                "# Not specifications\nREADME.md\nnode_modules/\n",
+		"README.md":                   "# About these specs\n",
+		"parser.md":                   "# Parser\n",
+		"web/node_modules/pkg/doc.md": "# Package docs\n",
+		"web/ui.md":                   "# UI\n",
+		"web/README.md":               "# Web readme\n",
+		"notes/.alignignore":          "*.md\n!decisions.md\n",
+		"notes/todo.md":               "# Todo\n",
+		"notes/decisions.md":          "# Decisions\n",
+	})
+
+	specification, err := ParseDirectory(dir)
+	assert.NoError(t, err)
+
+	var titles []string
+	var walk func(sections []*spec.Section)
+	walk = func(sections []*spec.Section) {
+		for _, section := range sections {
+			titles = append(titles, section.Title)
+			walk(section.Children)
+		}
+	}
+	walk(specification.Sections)
+	assert.Equal(t, []string{"Parser", "Notes", "Decisions", "Web", "UI"}, titles)
+}
+
+func TestParseDirectoryWithOptions(t *testing.T) {
+	dir := t.TempDir()
+	writeSpecFiles(t, dir, map[string]string{
+		"parser.markdown":  "# Parser\n",
+		"checking.mdx":     "# Checking\n",
+		"notes.md":         "# Notes\n",
+		"vendor/lib.mdx":   "# Vendored\n",
+		"drafts/ideas.mdx": "# Ideas\n",
+		"drafts/keep.mdx":  "# Keep\n",
+	})
+
+	specification, err := ParseDirectoryWithOptions(dir, DirectoryOptions{
+		Extensions: []string{".markdown", ".mdx"},
+		Exclude:    []string{"vendor/", "drafts/ideas.mdx"},
+	})
+	assert.NoError(t, err)
+
+	var titles []string
+	for _, section := range specification.Sections {
+		titles = append(titles, section.Title)
+	}
+	assert.Equal(t, []string{"Checking", "Parser", "Drafts"}, titles)
+	assert.Equal(t, "Keep", specification.Sections[2].Children[0].Title)
+}
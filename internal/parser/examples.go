@@ -0,0 +1,112 @@
+package parser
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/Alge/aligned/internal/spec"
+)
+
+// placeholderPattern matches a {column} placeholder in a templated test reference
+var placeholderPattern = regexp.MustCompile(`\{([A-Za-z_][A-Za-z0-9_-]*)\}`)
+
+// examplesTable is a markdown table in a section's content
+type examplesTable struct {
+	lines     []string
+	firstLine int // Line of the header row
+}
+
+// expandExamples returns one leaf section per row of the first table that has
+// a column for every {column} placeholder in the section's test references,
+// with the placeholders replaced by the row's values. The section itself
+// stops referencing tests, as its rows are the requirements. Returns nil if
+// the section has no templated references or no table provides their columns.
+func expandExamples(section *spec.Section, tables []examplesTable) []*spec.Section {
+	var placeholders []string
+	for _, test := range section.TestNames {
+		for _, match := range placeholderPattern.FindAllStringSubmatch(test, -1) {
+			placeholders = append(placeholders, match[1])
+		}
+	}
+	if len(placeholders) == 0 {
+		return nil
+	}
+	placeholders = uniqueStrings(placeholders)
+
+	for _, table := range tables {
+		if len(table.lines) < 2 || !tableDelimiterPattern.MatchString(table.lines[1]) {
+			continue
+		}
+		columns := make(map[string]int)
+		for i, cell := range splitTableRow(table.lines[0]) {
+			columns[strings.Trim(cell, "`*_ ")] = i
+		}
+		if !hasColumns(columns, placeholders) {
+			continue
+		}
+
+		rows := []*spec.Section{}
+		for i, line := range table.lines[2:] {
+			cells := splitTableRow(line)
+			value := func(column string) string {
+				if index := columns[column]; index < len(cells) {
+					return strings.Trim(cells[index], "`")
+				}
+				return ""
+			}
+
+			var tests []string
+			for _, test := range section.TestNames {
+				tests = append(tests, placeholderPattern.ReplaceAllStringFunc(test, func(placeholder string) string {
+					return value(placeholder[1 : len(placeholder)-1])
+				}))
+			}
+			var values []string
+			for _, placeholder := range placeholders {
+				values = append(values, value(placeholder))
+			}
+
+			row := &spec.Section{
+				Title:        strings.Join(values, ", "),
+				TestName:     tests[0],
+				TestNames:    tests,
+				TestMatch:    section.TestMatch,
+				TestExpanded: true,
+				Line:         table.firstLine + 2 + i,
+				TestLine:     table.firstLine + 2 + i,
+				Children:     []*spec.Section{},
+			}
+			rows = append(rows, row)
+		}
+
+		section.TestName = ""
+		section.TestNames = nil
+		section.TestLine = 0
+		section.TestLines = nil
+		return rows
+	}
+	return nil
+}
+
+// hasColumns returns true if every name is a column of the table
+func hasColumns(columns map[string]int, names []string) bool {
+	for _, name := range names {
+		if _, ok := columns[name]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// uniqueStrings returns the values without duplicates, keeping their order
+func uniqueStrings(values []string) []string {
+	var unique []string
+	seen := make(map[string]bool)
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			unique = append(unique, value)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,84 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseMarkdownExamples(t *testing.T) {
+	t.Run("one requirement per example", func(t *testing.T) {
+		content := "# Parser\n\n" +
+			"## Parse numbers\n" +
+			"**Test:** `parser.TestParse/{case}`\n\n" +
+			"| case | input | expected |\n" +
+			"|------|-------|----------|\n" +
+			"| `integer` | 42 | 42 |\n" +
+			"| negative | -1 | -1 |\n"
+
+		result, err := ParseMarkdown(content)
+		if err != nil {
+			t.Fatalf("ParseMarkdown() error = %v", err)
+		}
+
+		section := result.Sections[0].Children[0]
+		if section.HasTest() {
+			t.Errorf("the templated section should not reference tests itself, got %q", section.TestNames)
+		}
+		if len(section.Children) != 2 {
+			t.Fatalf("Parse numbers has %d children, want 2", len(section.Children))
+		}
+		integer := section.Children[0]
+		if integer.Title != "integer" || integer.TestName != "parser.TestParse/integer" || integer.Level != 3 {
+			t.Errorf("first example = %q (%q, level %d)", integer.Title, integer.TestName, integer.Level)
+		}
+		if integer.Line != 8 || integer.TestLine != 8 {
+			t.Errorf("first example Line, TestLine = %d, %d, want 8, 8", integer.Line, integer.TestLine)
+		}
+		if negative := section.Children[1]; negative.TestName != "parser.TestParse/negative" {
+			t.Errorf("second example test = %q", negative.TestName)
+		}
+		if !strings.Contains(section.Content, "| negative | -1 | -1 |") {
+			t.Error("the examples table should stay part of the section content")
+		}
+	})
+
+	t.Run("several placeholders and references", func(t *testing.T) {
+		content := "## Parse dates\n" +
+			"**Tests:** `tests/test_dates.py::test_parse[{format}-{zone}]`, `dates.TestFormat`\n\n" +
+			"| format | zone |\n" +
+			"|---|---|\n" +
+			"| iso | utc |\n"
+
+		result, err := ParseMarkdown(content)
+		if err != nil {
+			t.Fatalf("ParseMarkdown() error = %v", err)
+		}
+
+		example := result.Sections[0].Children[0]
+		if example.Title != "iso, utc" {
+			t.Errorf("Title = %q, want %q", example.Title, "iso, utc")
+		}
+		want := []string{"tests/test_dates.py::test_parse[iso-utc]", "dates.TestFormat"}
+		if strings.Join(example.TestNames, " ") != strings.Join(want, " ") {
+			t.Errorf("TestNames = %q, want %q", example.TestNames, want)
+		}
+	})
+
+	t.Run("references without a matching table are kept", func(t *testing.T) {
+		content := "## Render\n" +
+			"**Test:** `ui.TestRender/{theme}`\n\n" +
+			"| mode | value |\n" +
+			"|---|---|\n" +
+			"| dark | 1 |\n"
+
+		result, err := ParseMarkdown(content)
+		if err != nil {
+			t.Fatalf("ParseMarkdown() error = %v", err)
+		}
+
+		section := result.Sections[0]
+		if len(section.Children) != 0 || section.TestName != "ui.TestRender/{theme}" {
+			t.Errorf("section = %d children, test %q; want it unchanged", len(section.Children), section.TestName)
+		}
+	})
+}
@@ -0,0 +1,237 @@
+package parser
+
+import (
+	"bufio"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// ignoreFileName is the gitignore-style file listing paths that are not spec files
+const ignoreFileName = ".alignignore"
+
+// DirectoryOptions controls which files are loaded from a spec directory
+type DirectoryOptions struct {
+	Extensions []string // Extensions of spec files, such as ".md" (default) or ".mdx"
+	Exclude    []string // gitignore-style patterns relative to the directory, in addition to .alignignore files
+}
+
+// ignoreRule is a single gitignore-style pattern
+type ignoreRule struct {
+	base    string         // Directory the pattern is relative to
+	pattern *regexp.Regexp // Matches slash-separated paths relative to base
+	negate  bool           // "!pattern" re-includes a previously ignored path
+	dirOnly bool           // "pattern/" only matches directories
+}
+
+// specFiles decides which files in a spec directory are loaded on their own
+type specFiles struct {
+	extensions []string
+	rules      []ignoreRule
+	read       map[string]bool // Directories whose .alignignore has been read
+	included   map[string]bool // Absolute paths of files included by another file
+}
+
+// newSpecFiles prepares the file filter for a spec directory
+func newSpecFiles(rootPath string, options DirectoryOptions) (*specFiles, error) {
+	files := &specFiles{
+		extensions: []string{".md"},
+		read:       make(map[string]bool),
+		included:   make(map[string]bool),
+	}
+	if len(options.Extensions) > 0 {
+		files.extensions = nil
+		for _, extension := range options.Extensions {
+			if !strings.HasPrefix(extension, ".") {
+				extension = "." + extension
+			}
+			files.extensions = append(files.extensions, extension)
+		}
+	}
+
+	for _, pattern := range options.Exclude {
+		rule, ok, err := parseIgnoreRule(rootPath, pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exclude pattern '%s': %w", pattern, err)
+		}
+		if ok {
+			files.rules = append(files.rules, rule)
+		}
+	}
+	return files, nil
+}
+
+// enter reads the .alignignore file of a directory, if it has one. Its rules
+// apply to everything below the directory.
+func (f *specFiles) enter(dirPath string) error {
+	if f.read[dirPath] {
+		return nil
+	}
+	f.read[dirPath] = true
+
+	file, err := os.Open(filepath.Join(dirPath, ignoreFileName))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		rule, ok, err := parseIgnoreRule(dirPath, scanner.Text())
+		if err != nil {
+			return fmt.Errorf("%s:%d: %w", file.Name(), line, err)
+		}
+		if ok {
+			f.rules = append(f.rules, rule)
+		}
+	}
+	return scanner.Err()
+}
+
+// isIgnored returns true if the last rule matching the path ignores it
+func (f *specFiles) isIgnored(path string, isDir bool) bool {
+	ignored := false
+	for _, rule := range f.rules {
+		if rule.dirOnly && !isDir {
+			continue
+		}
+		relative, err := filepath.Rel(rule.base, path)
+		if err != nil || relative == "." || strings.HasPrefix(relative, "..") {
+			continue
+		}
+		if rule.pattern.MatchString(filepath.ToSlash(relative)) {
+			ignored = !rule.negate
+		}
+	}
+	return ignored
+}
+
+// isSpecFile returns true if the file name has one of the spec file extensions
+func (f *specFiles) isSpecFile(name string) bool {
+	return f.extension(name) != ""
+}
+
+// extension returns the spec file extension of the name, or "" if it has none.
+// Gherkin feature files are always spec files.
+func (f *specFiles) extension(name string) string {
+	for _, extension := range f.extensions {
+		if strings.HasSuffix(name, extension) && len(name) > len(extension) {
+			return extension
+		}
+	}
+	if strings.HasSuffix(name, gherkinExtension) && len(name) > len(gherkinExtension) {
+		return gherkinExtension
+	}
+	return ""
+}
+
+// isIncluded returns true if the file is spliced into another file by an include directive
+func (f *specFiles) isIncluded(path string) bool {
+	absolute, err := filepath.Abs(path)
+	return err == nil && f.included[absolute]
+}
+
+// walk calls fn for every spec file under rootPath that is not ignored
+func (f *specFiles) walk(rootPath string, fn func(path string) error) error {
+	return filepath.WalkDir(rootPath, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if path != rootPath && f.isIgnored(path, true) {
+				return filepath.SkipDir
+			}
+			return f.enter(path)
+		}
+		if !f.isSpecFile(entry.Name()) || f.isIgnored(path, false) {
+			return nil
+		}
+		return fn(path)
+	})
+}
+
+// parseIgnoreRule parses one line of gitignore syntax relative to base.
+// Returns false for blank lines and comments.
+func parseIgnoreRule(base string, line string) (ignoreRule, bool, error) {
+	line = strings.TrimRight(line, " \t\r")
+	if line == "" || strings.HasPrefix(line, "#") {
+		return ignoreRule{}, false, nil
+	}
+
+	rule := ignoreRule{base: base}
+	if strings.HasPrefix(line, "!") {
+		rule.negate = true
+		line = line[1:]
+	} else if strings.HasPrefix(line, `\`) {
+		line = line[1:] // Escaped leading "#" or "!"
+	}
+	if strings.HasSuffix(line, "/") {
+		rule.dirOnly = true
+		line = strings.TrimRight(line, "/")
+	}
+
+	// Patterns without a slash match at any depth, others are anchored to base
+	anchored := strings.Contains(line, "/")
+	line = strings.TrimPrefix(line, "/")
+	if line == "" {
+		return ignoreRule{}, false, nil
+	}
+
+	expression := globToRegexp(line)
+	if !anchored {
+		expression = "(?:.*/)?" + expression
+	}
+	pattern, err := regexp.Compile("^" + expression + "$")
+	if err != nil {
+		return ignoreRule{}, false, err
+	}
+	rule.pattern = pattern
+	return rule, true, nil
+}
+
+// globToRegexp translates a gitignore glob into a regular expression
+func globToRegexp(glob string) string {
+	var expression strings.Builder
+	for i := 0; i < len(glob); i++ {
+		switch c := glob[i]; c {
+		case '*':
+			if strings.HasPrefix(glob[i:], "**/") {
+				expression.WriteString("(?:.*/)?")
+				i += 2
+			} else if strings.HasPrefix(glob[i:], "**") {
+				expression.WriteString(".*")
+				i++
+			} else {
+				expression.WriteString("[^/]*")
+			}
+		case '?':
+			expression.WriteString("[^/]")
+		case '[':
+			end := strings.IndexByte(glob[i+1:], ']')
+			if end < 0 {
+				expression.WriteString(`\[`)
+				continue
+			}
+			class := glob[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			expression.WriteString("[" + strings.ReplaceAll(class, `\`, `\\`) + "]")
+			i += end + 1
+		case '\\':
+			if i+1 < len(glob) {
+				i++
+				expression.WriteString(regexp.QuoteMeta(string(glob[i])))
+			}
+		default:
+			expression.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	return expression.String()
+}
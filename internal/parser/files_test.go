@@ -0,0 +1,60 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIgnorePatterns(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		isDir   bool
+		ignored bool
+	}{
+		{"README.md", "README.md", false, true},
+		{"README.md", "docs/README.md", false, true},
+		{"/README.md", "docs/README.md", false, false},
+		{"*.md", "notes/todo.md", false, true},
+		{"node_modules/", "web/node_modules", true, true},
+		{"node_modules/", "node_modules", false, false},
+		{"docs/*.md", "docs/guide.md", false, true},
+		{"docs/*.md", "docs/api/guide.md", false, false},
+		{"docs/**/*.md", "docs/api/guide.md", false, true},
+		{"**/drafts", "a/b/drafts", true, true},
+		{"CHANGELOG?.md", "CHANGELOG2.md", false, true},
+		{"[Rr]eadme.md", "readme.md", false, true},
+		{"[!R]eadme.md", "Readme.md", false, false},
+		{"# comment", "# comment", false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.path, func(t *testing.T) {
+			files, err := newSpecFiles("/spec", DirectoryOptions{Exclude: []string{tt.pattern}})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.ignored, files.isIgnored("/spec/"+tt.path, tt.isDir))
+		})
+	}
+
+	t.Run("negated patterns re-include paths", func(t *testing.T) {
+		files, err := newSpecFiles("/spec", DirectoryOptions{Exclude: []string{"*.md", "!keep.md"}})
+		assert.NoError(t, err)
+		assert.True(t, files.isIgnored("/spec/drop.md", false))
+		assert.False(t, files.isIgnored("/spec/keep.md", false))
+	})
+}
+
+func TestSpecFileExtensions(t *testing.T) {
+	files, err := newSpecFiles("/spec", DirectoryOptions{})
+	assert.NoError(t, err)
+	assert.True(t, files.isSpecFile("parser.md"))
+	assert.False(t, files.isSpecFile("parser.markdown"))
+
+	files, err = newSpecFiles("/spec", DirectoryOptions{Extensions: []string{"markdown", ".mdx"}})
+	assert.NoError(t, err)
+	assert.True(t, files.isSpecFile("parser.markdown"))
+	assert.True(t, files.isSpecFile("parser.mdx"))
+	assert.False(t, files.isSpecFile("parser.md"), "configured extensions replace the default")
+	assert.False(t, files.isSpecFile(".mdx"))
+}
@@ -0,0 +1,111 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Alge/aligned/internal/spec"
+	"gopkg.in/yaml.v3"
+)
+
+// frontMatter is the YAML layout of a metadata block at the top of a spec file
+type frontMatter struct {
+	Owner  string     `yaml:"owner"`
+	Status string     `yaml:"status"`
+	Tags   stringList `yaml:"tags"`
+	Title  string     `yaml:"title"`
+	Order  *int       `yaml:"order"`
+}
+
+// stringList accepts either a YAML sequence or a comma-separated string
+type stringList []string
+
+func (l *stringList) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*l = splitList(node.Value)
+		return nil
+	}
+
+	var values []string
+	if err := node.Decode(&values); err != nil {
+		return err
+	}
+	*l = values
+	return nil
+}
+
+// splitList splits a comma-separated list, trimming whitespace and dropping empty entries
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// ExtractFrontMatter splits a leading `---` delimited YAML block from markdown content.
+// Returns the parsed metadata (nil if there is no front matter), the remaining content,
+// and the number of lines the block occupied so line numbers can be kept accurate.
+func ExtractFrontMatter(content string) (*spec.Metadata, string, int, error) {
+	lines := strings.Split(content, "\n")
+	if len(lines) == 0 || strings.TrimRight(lines[0], " \t\r") != "---" {
+		return nil, content, 0, nil
+	}
+
+	// Find the closing delimiter
+	end := -1
+	for i := 1; i < len(lines); i++ {
+		trimmed := strings.TrimRight(lines[i], " \t\r")
+		if trimmed == "---" || trimmed == "..." {
+			end = i
+			break
+		}
+	}
+	if end == -1 {
+		// An unterminated block is a thematic break, not front matter
+		return nil, content, 0, nil
+	}
+
+	var fm frontMatter
+	block := strings.Join(lines[1:end], "\n")
+	if err := yaml.Unmarshal([]byte(block), &fm); err != nil {
+		return nil, content, 0, fmt.Errorf("invalid front matter: %w", err)
+	}
+
+	metadata := &spec.Metadata{
+		Owner:  fm.Owner,
+		Status: fm.Status,
+		Tags:   fm.Tags,
+		Title:  fm.Title,
+		Order:  fm.Order,
+	}
+	return metadata, strings.Join(lines[end+1:], "\n"), end + 1, nil
+}
+
+// applyMetadata attaches file metadata to every section parsed from that file
+// and applies the display title to the first top-level section. Sections spliced
+// in from an included file keep that file's metadata.
+func applyMetadata(sections []*spec.Section, metadata *spec.Metadata) {
+	if metadata == nil {
+		return
+	}
+
+	var walk func(*spec.Section)
+	walk = func(section *spec.Section) {
+		if section.Metadata == nil {
+			section.Metadata = metadata
+		}
+		for _, child := range section.Children {
+			walk(child)
+		}
+	}
+	for _, section := range sections {
+		walk(section)
+	}
+
+	if metadata.Title != "" && len(sections) > 0 {
+		sections[0].Title = metadata.Title
+	}
+}
@@ -0,0 +1,77 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractFrontMatter(t *testing.T) {
+	t.Run("parses metadata and strips the block", func(t *testing.T) {
+		content := `---
+owner: platform-team
+status: approved
+tags: [security, api]
+title: Authentication
+---
+# Auth
+
+## Login
+**Test:** ` + "`TestLogin`" + `
+`
+		metadata, rest, lines, err := ExtractFrontMatter(content)
+		assert.NoError(t, err)
+		assert.NotNil(t, metadata)
+		assert.Equal(t, "platform-team", metadata.Owner)
+		assert.Equal(t, "approved", metadata.Status)
+		assert.Equal(t, []string{"security", "api"}, metadata.Tags)
+		assert.Equal(t, "Authentication", metadata.Title)
+		assert.Equal(t, 6, lines)
+		assert.True(t, len(rest) > 0 && rest[:6] == "# Auth")
+	})
+
+	t.Run("accepts comma separated tags", func(t *testing.T) {
+		metadata, _, _, err := ExtractFrontMatter("---\ntags: security, api\n---\n# Auth\n")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"security", "api"}, metadata.Tags)
+	})
+
+	t.Run("content without front matter is unchanged", func(t *testing.T) {
+		content := "# Auth\n\n---\n\nText after a thematic break.\n"
+		metadata, rest, lines, err := ExtractFrontMatter(content)
+		assert.NoError(t, err)
+		assert.Nil(t, metadata)
+		assert.Equal(t, content, rest)
+		assert.Equal(t, 0, lines)
+	})
+
+	t.Run("invalid yaml is an error", func(t *testing.T) {
+		_, _, _, err := ExtractFrontMatter("---\nowner: [unclosed\n---\n# Auth\n")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "front matter")
+	})
+}
+
+func TestParseMarkdownFrontMatter(t *testing.T) {
+	content := `---
+owner: platform-team
+status: draft
+title: Authentication
+---
+# Auth spec
+
+## Login
+**Test:** ` + "`TestLogin`" + `
+`
+	result, err := ParseMarkdown(content)
+	assert.NoError(t, err)
+	assert.Len(t, result.Sections, 1)
+
+	root := result.Sections[0]
+	assert.Equal(t, "Authentication", root.Title, "display title should replace the first heading")
+	assert.Empty(t, root.Content, "front matter must not leak into section content")
+	assert.NotNil(t, result.Metadata)
+	assert.Same(t, result.Metadata, root.Metadata)
+	assert.Same(t, result.Metadata, root.Children[0].Metadata, "metadata should be attached to every section")
+	assert.Equal(t, "platform-team", root.Children[0].Metadata.Owner)
+}
@@ -0,0 +1,352 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/Alge/aligned/internal/spec"
+)
+
+// gherkinExtension is the extension of Gherkin feature files
+const gherkinExtension = ".feature"
+
+// gherkinTestTag is the tag prefix that references a test, as in @test:pkg.TestLogin
+const gherkinTestTag = "@test:"
+
+// gherkinTestComment matches a "# Test: pkg.TestLogin" comment
+var gherkinTestComment = regexp.MustCompile(`^#\s*Tests?:\s*(.+)$`)
+
+// gherkinPlaceholder matches a <column> placeholder in a Scenario Outline
+var gherkinPlaceholder = regexp.MustCompile(`<([^<>]+)>`)
+
+// gherkinKeyword is a Gherkin keyword that starts a new element
+type gherkinKeyword struct {
+	name string
+	kind gherkinKind
+}
+
+type gherkinKind int
+
+const (
+	gherkinFeature gherkinKind = iota
+	gherkinRule
+	gherkinBackground
+	gherkinScenario
+	gherkinOutline
+	gherkinExamples
+)
+
+// gherkinKeywords lists the keywords, longer ones before their prefixes
+var gherkinKeywords = []gherkinKeyword{
+	{"Feature", gherkinFeature},
+	{"Rule", gherkinRule},
+	{"Background", gherkinBackground},
+	{"Scenario Outline", gherkinOutline},
+	{"Scenario Template", gherkinOutline},
+	{"Scenario", gherkinScenario},
+	{"Examples", gherkinExamples},
+	{"Scenarios", gherkinExamples},
+	{"Example", gherkinScenario},
+}
+
+// gherkinParser holds the state of ParseGherkin while it reads lines
+type gherkinParser struct {
+	sections      []*spec.Section // Flat list in document order, ready for buildTree
+	current       *spec.Section   // Element receiving steps and description lines
+	scenarioLevel int             // Level of scenarios: below the Feature or below a Rule
+
+	pendingTags     []string // Tags and test references seen since the last element
+	pendingTests    []string
+	pendingTestLine int
+
+	outline       *spec.Section // Scenario Outline whose Examples are being read
+	outlineTests  []string      // Test references of the outline, before substitution
+	examplesTags  []string      // Tags of the current Examples block
+	examplesTests []string      // Test references of the current Examples block
+	header        []string      // Column names of the current Examples table, nil before the header row
+	inExamples    bool
+}
+
+// ParseGherkin parses a Gherkin feature file into a specification. The Feature
+// is the top-level section, Rules group scenarios, and every Scenario is a leaf.
+// Tests are referenced with @test:name tags or "# Test: name" comments. A
+// Scenario Outline has one leaf per Examples row, with <column> placeholders in
+// its name and test references replaced by the row's values.
+func ParseGherkin(content string) (*spec.Specification, error) {
+	p := &gherkinParser{}
+	lines := strings.Split(content, "\n")
+	docString := ""
+
+	for i, raw := range lines {
+		line := i + 1
+		text := strings.TrimSpace(raw)
+
+		// Skip doc strings attached to steps
+		if docString != "" {
+			if strings.HasPrefix(text, docString) {
+				docString = ""
+			}
+			continue
+		}
+		if strings.HasPrefix(text, `"""`) || strings.HasPrefix(text, "```") {
+			docString = text[:3]
+			continue
+		}
+
+		switch {
+		case text == "":
+		case strings.HasPrefix(text, "#"):
+			if match := gherkinTestComment.FindStringSubmatch(text); match != nil {
+				p.addPendingTests(splitTestList(match[1]), line)
+			}
+		case strings.HasPrefix(text, "@"):
+			p.addTags(text, line)
+		case strings.HasPrefix(text, "|"):
+			if err := p.tableRow(text, line); err != nil {
+				return nil, err
+			}
+		default:
+			keyword, title, ok := matchGherkinKeyword(text)
+			if !ok {
+				p.content(text)
+				continue
+			}
+			if err := p.element(keyword, title, line); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return &spec.Specification{
+		Sections: buildTree(p.sections),
+	}, nil
+}
+
+// matchGherkinKeyword splits "Scenario: Title" into its keyword and title
+func matchGherkinKeyword(text string) (gherkinKeyword, string, bool) {
+	for _, keyword := range gherkinKeywords {
+		if strings.HasPrefix(text, keyword.name+":") {
+			return keyword, strings.TrimSpace(text[len(keyword.name)+1:]), true
+		}
+	}
+	return gherkinKeyword{}, "", false
+}
+
+// addTags records the tags on a line. @test: tags are test references.
+func (p *gherkinParser) addTags(text string, line int) {
+	if comment := strings.Index(text, " #"); comment >= 0 {
+		text = text[:comment]
+	}
+	for _, tag := range strings.Fields(text) {
+		if strings.HasPrefix(tag, gherkinTestTag) {
+			p.addPendingTests([]string{strings.TrimPrefix(tag, gherkinTestTag)}, line)
+		} else if name := strings.TrimPrefix(tag, "@"); name != "" {
+			p.pendingTags = append(p.pendingTags, name)
+		}
+	}
+}
+
+func (p *gherkinParser) addPendingTests(tests []string, line int) {
+	if len(tests) > 0 && p.pendingTestLine == 0 {
+		p.pendingTestLine = line
+	}
+	p.pendingTests = append(p.pendingTests, tests...)
+}
+
+// content handles a step or description line. Test comments seen since the
+// last element belong to the element the line is part of.
+func (p *gherkinParser) content(text string) {
+	if p.current == nil {
+		return
+	}
+	if len(p.pendingTests) > 0 {
+		if p.inExamples {
+			p.examplesTests = append(p.examplesTests, p.pendingTests...)
+		} else {
+			p.addTests(p.current, p.pendingTests, p.pendingTestLine)
+			if p.current == p.outline {
+				p.outlineTests = append(p.outlineTests, p.pendingTests...)
+			}
+		}
+		p.clearPending()
+	}
+	if p.current.Content != "" {
+		p.current.Content += "\n"
+	}
+	p.current.Content += text
+}
+
+// element starts a Feature, Rule, Background, Scenario or Examples block
+func (p *gherkinParser) element(keyword gherkinKeyword, title string, line int) error {
+	defer p.clearPending()
+
+	if keyword.kind != gherkinFeature && len(p.sections) == 0 {
+		return fmt.Errorf("line %d: %s outside a Feature", line, keyword.name)
+	}
+	if keyword.kind != gherkinExamples {
+		p.outline = nil
+		p.inExamples = false
+	}
+
+	level := p.scenarioLevel
+	switch keyword.kind {
+	case gherkinFeature:
+		if len(p.sections) > 0 {
+			return fmt.Errorf("line %d: only one Feature is allowed per file", line)
+		}
+		level = 1
+		p.scenarioLevel = 2
+	case gherkinRule:
+		level = 2
+		p.scenarioLevel = 3
+	case gherkinBackground:
+		// Background steps are shared setup, not a requirement
+		p.current = nil
+		return nil
+	case gherkinExamples:
+		if p.outline == nil {
+			return fmt.Errorf("line %d: Examples outside a Scenario Outline", line)
+		}
+		p.current = p.outline
+		p.inExamples = true
+		p.header = nil
+		p.examplesTags = p.pendingTags
+		p.examplesTests = p.pendingTests
+		return nil
+	}
+
+	title, id := ExtractRequirementID(title)
+	section := &spec.Section{
+		Level:    level,
+		Title:    title,
+		ID:       id,
+		Tags:     p.pendingTags,
+		Line:     line,
+		Children: []*spec.Section{},
+	}
+	p.addTests(section, p.pendingTests, p.pendingTestLine)
+	p.sections = append(p.sections, section)
+	p.current = section
+
+	if keyword.kind == gherkinOutline {
+		p.outline = section
+		p.outlineTests = p.pendingTests
+	}
+	return nil
+}
+
+// tableRow handles a row of an Examples table. Data tables of steps are ignored.
+func (p *gherkinParser) tableRow(text string, line int) error {
+	if !p.inExamples {
+		return nil
+	}
+	cells := splitTableRow(text)
+	if p.header == nil {
+		p.header = cells
+		return nil
+	}
+	if len(cells) != len(p.header) {
+		return fmt.Errorf("line %d: Examples row has %d cells, expected %d", line, len(cells), len(p.header))
+	}
+
+	values := make(map[string]string)
+	for i, column := range p.header {
+		values[column] = cells[i]
+	}
+	substitute := func(text string) string {
+		return gherkinPlaceholder.ReplaceAllStringFunc(text, func(placeholder string) string {
+			if value, ok := values[placeholder[1:len(placeholder)-1]]; ok {
+				return value
+			}
+			return placeholder
+		})
+	}
+
+	title := substitute(p.outline.Title)
+	if title == p.outline.Title {
+		title = fmt.Sprintf("%s (%s)", title, strings.Join(cells, ", "))
+	}
+	row := &spec.Section{
+		Level:    p.outline.Level + 1,
+		Title:    title,
+		Tags:     p.examplesTags,
+		Line:     line,
+		Children: []*spec.Section{},
+	}
+	var tests []string
+	for _, test := range append(append([]string{}, p.outlineTests...), p.examplesTests...) {
+		tests = append(tests, substitute(test))
+	}
+	p.addTests(row, tests, line)
+	row.TestExpanded = len(tests) > 0
+
+	// The rows are the leaves, the outline only groups them
+	p.outline.TestName = ""
+	p.outline.TestNames = nil
+	p.outline.TestLine = 0
+	p.sections = append(p.sections, row)
+	return nil
+}
+
+// addTests appends test references to a section, without duplicates
+func (p *gherkinParser) addTests(section *spec.Section, tests []string, line int) {
+	for _, test := range tests {
+		if !slices.Contains(section.TestNames, test) {
+			section.TestNames = append(section.TestNames, test)
+		}
+	}
+	if len(section.TestNames) > 0 {
+		section.TestName = section.TestNames[0]
+		if section.TestLine == 0 {
+			section.TestLine = line
+		}
+	}
+}
+
+func (p *gherkinParser) clearPending() {
+	p.pendingTags = nil
+	p.pendingTests = nil
+	p.pendingTestLine = 0
+}
+
+// splitTestList returns the backticked test names in text, which may contain
+// spaces as Vitest and ExUnit names do, or the comma-separated names if
+// nothing is backticked
+func splitTestList(text string) []string {
+	var tests []string
+	for _, m := range backtickPattern.FindAllStringSubmatch(text, -1) {
+		tests = append(tests, m[1])
+	}
+	if len(tests) > 0 {
+		return tests
+	}
+	return splitList(text)
+}
+
+// splitTableRow returns the trimmed cells of a "| a | b |" row, honoring \| escapes
+func splitTableRow(text string) []string {
+	text = strings.TrimSpace(text)
+	text = strings.TrimPrefix(text, "|")
+	if strings.HasSuffix(text, "|") && !strings.HasSuffix(text, `\|`) {
+		text = text[:len(text)-1]
+	}
+
+	var cells []string
+	var cell strings.Builder
+	for i := 0; i < len(text); i++ {
+		if text[i] == '\\' && i+1 < len(text) && text[i+1] == '|' {
+			cell.WriteByte('|')
+			i++
+			continue
+		}
+		if text[i] == '|' {
+			cells = append(cells, strings.TrimSpace(cell.String()))
+			cell.Reset()
+			continue
+		}
+		cell.WriteByte(text[i])
+	}
+	return append(cells, strings.TrimSpace(cell.String()))
+}
@@ -0,0 +1,176 @@
+package parser
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseGherkin(t *testing.T) {
+	content := `# language: en
+@auth
+Feature: Login
+  Users sign in with a password.
+
+  Background:
+    Given a registered user
+
+  @test:auth.TestLoginSucceeds @smoke
+  Scenario: Successful login
+    When the user signs in
+    Then the dashboard is shown
+
+  Rule: Lockout
+
+    Scenario: Lock after failed attempts {#AUTH-3}
+      # Test: auth.TestLockout
+      When the user fails to sign in 3 times
+      """
+      Scenario: not a scenario
+      """
+      Then the account is locked
+
+    Scenario: Unlock by email
+      Then an unlock link is sent
+
+  Scenario: Reject empty password
+    # Tests: ` + "`src/login.test.js > login > rejects empty password`, `test/login_test.exs:LoginTest:test rejects empty password`" + `
+    # Test: auth.TestRejectEmpty, auth.TestRejectBlank
+    Then an error is shown
+`
+
+	specification, err := ParseGherkin(content)
+	assert.NoError(t, err)
+	assert.Len(t, specification.Sections, 1)
+
+	feature := specification.Sections[0]
+	assert.Equal(t, "Login", feature.Title)
+	assert.Equal(t, 1, feature.Level)
+	assert.Equal(t, 3, feature.Line)
+	assert.Equal(t, []string{"auth"}, feature.Tags)
+	assert.Len(t, feature.Children, 2, "the Background is not a requirement")
+
+	login := feature.Children[0]
+	assert.Equal(t, "Successful login", login.Title)
+	assert.Equal(t, 2, login.Level)
+	assert.Equal(t, "auth.TestLoginSucceeds", login.TestName)
+	assert.Equal(t, []string{"smoke"}, login.Tags)
+	assert.Equal(t, 9, login.TestLine)
+	assert.Equal(t, 10, login.Line)
+	assert.Contains(t, login.Content, "Then the dashboard is shown")
+
+	rule := feature.Children[1]
+	assert.Equal(t, "Lockout", rule.Title)
+	assert.Len(t, rule.Children, 3, "doc strings are skipped")
+
+	lockout := rule.Children[0]
+	assert.Equal(t, 3, lockout.Level)
+	assert.Equal(t, "Lock after failed attempts", lockout.Title)
+	assert.Equal(t, "AUTH-3", lockout.ID)
+	assert.Equal(t, "auth.TestLockout", lockout.TestName, "a test comment inside a scenario belongs to it")
+	assert.Equal(t, 17, lockout.TestLine)
+
+	unlock := rule.Children[1]
+	assert.Equal(t, "Unlock by email", unlock.Title)
+	assert.Empty(t, unlock.TestNames)
+	assert.True(t, unlock.RequiresTest())
+
+	reject := rule.Children[2]
+	assert.Equal(t, []string{
+		"src/login.test.js > login > rejects empty password",
+		"test/login_test.exs:LoginTest:test rejects empty password",
+		"auth.TestRejectEmpty",
+		"auth.TestRejectBlank",
+	}, reject.TestNames, "backticked names may contain spaces, others are separated by commas")
+}
+
+func TestParseGherkinScenarioOutline(t *testing.T) {
+	content := `Feature: Passwords
+
+  # Test: auth.TestPasswordStrength/<password>
+  Scenario Outline: Reject weak password <password>
+    When the user chooses "<password>"
+    Then the password is rejected
+
+    Examples:
+      | password |
+      | 123456   |
+      | qwerty   |
+
+  @test:auth.TestRoles
+  Scenario Outline: Role permissions
+    Given a user with role <role>
+    Then they can <action>
+
+    @admin
+    Examples: Administrators
+      | role  | action       |
+      | admin | delete users |
+`
+
+	specification, err := ParseGherkin(content)
+	assert.NoError(t, err)
+
+	feature := specification.Sections[0]
+	assert.Len(t, feature.Children, 2)
+
+	weak := feature.Children[0]
+	assert.Equal(t, "Reject weak password <password>", weak.Title)
+	assert.Empty(t, weak.TestNames, "the outline only groups its rows")
+	assert.Len(t, weak.Children, 2)
+	assert.Equal(t, "Reject weak password 123456", weak.Children[0].Title)
+	assert.Equal(t, "auth.TestPasswordStrength/123456", weak.Children[0].TestName)
+	assert.Equal(t, 10, weak.Children[0].Line)
+	assert.Equal(t, "Reject weak password qwerty", weak.Children[1].Title)
+	assert.Equal(t, "auth.TestPasswordStrength/qwerty", weak.Children[1].TestName)
+	assert.Equal(t, 3, weak.Children[0].Level)
+
+	roles := feature.Children[1]
+	assert.Len(t, roles.Children, 1)
+	assert.Equal(t, "Role permissions (admin, delete users)", roles.Children[0].Title, "rows are named by their values when the name has no placeholders")
+	assert.Equal(t, "auth.TestRoles", roles.Children[0].TestName)
+	assert.Equal(t, []string{"admin"}, roles.Children[0].Tags)
+}
+
+func TestParseGherkinErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		err     string
+	}{
+		{"scenario before feature", "Scenario: Orphan\n", "line 1: Scenario outside a Feature"},
+		{"examples without outline", "Feature: F\nScenario: S\nExamples:\n", "line 3: Examples outside a Scenario Outline"},
+		{"two features", "Feature: A\nFeature: B\n", "line 2: only one Feature is allowed per file"},
+		{"row with missing cells", "Feature: F\nScenario Outline: S\nExamples:\n| a | b |\n| 1 |\n", "line 5: Examples row has 1 cells, expected 2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseGherkin(tt.content)
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+}
+
+func TestParseDirectoryWithFeatureFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeSpecFiles(t, dir, map[string]string{
+		"checkout.md":         "# Checkout\n\n## Pay by card\n**Test:** `shop.TestPayByCard`\n",
+		"login/login.feature": "Feature: Login\n\n  @test:auth.TestLogin\n  Scenario: Sign in\n",
+	})
+
+	specification, err := ParseDirectory(dir)
+	assert.NoError(t, err)
+	assert.Len(t, specification.Sections, 2)
+
+	login := specification.Sections[1]
+	assert.Equal(t, "Login", login.Title, "a feature file named after its directory supplies its heading")
+	assert.Equal(t, 1, login.Level)
+	assert.Equal(t, "auth.TestLogin", login.Children[0].TestName)
+	assert.Equal(t, filepath.Join(dir, "login", "login.feature"), login.Children[0].FilePath)
+	assert.Equal(t, 4, login.Children[0].Line)
+
+	_, err = ParseFile(filepath.Join(dir, "missing.feature"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,115 @@
+package parser
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/Alge/aligned/internal/spec"
+)
+
+// includeDirectivePattern matches an <!-- align:include path --> comment on its own line
+var includeDirectivePattern = regexp.MustCompile(`^ {0,3}<!--\s*align:include\s+(.+?)\s*-->\s*$`)
+
+// includeContext describes where markdown being parsed came from, so include
+// paths can be resolved and include cycles detected
+type includeContext struct {
+	path  string   // File being parsed, empty for content not read from a file
+	stack []string // Files being parsed, outermost first, including path
+}
+
+// resolve returns the path of an included file. Relative paths are relative to
+// the including file, or to the working directory for content without a file.
+func (c *includeContext) resolve(target string) string {
+	if filepath.IsAbs(target) || c.path == "" {
+		return filepath.Clean(target)
+	}
+	return filepath.Join(filepath.Dir(c.path), target)
+}
+
+// location returns "file:line" (or "line N" without a file) for error messages
+func (c *includeContext) location(line int) string {
+	if c.path == "" {
+		return fmt.Sprintf("line %d", line)
+	}
+	return fmt.Sprintf("%s:%d", c.path, line)
+}
+
+// include parses the file named by an include directive on the given line
+func (c *includeContext) include(target string, line int) (*spec.Specification, error) {
+	path := c.resolve(target)
+
+	for i, parent := range c.stack {
+		if sameFile(parent, path) {
+			chain := append(append([]string{}, c.stack[i:]...), path)
+			return nil, fmt.Errorf("%s: include cycle: %s", c.location(line), strings.Join(chain, " -> "))
+		}
+	}
+
+	included, err := parseFile(path, c.stack)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%s: cannot include %s: file not found", c.location(line), target)
+		}
+		return nil, err
+	}
+	return included, nil
+}
+
+// sameFile returns true if both paths refer to the same file
+func sameFile(a, b string) bool {
+	absA, errA := filepath.Abs(a)
+	absB, errB := filepath.Abs(b)
+	if errA != nil || errB != nil {
+		return filepath.Clean(a) == filepath.Clean(b)
+	}
+	return absA == absB
+}
+
+// spliceSections adjusts included sections to sit below a heading of the given
+// level and flattens them in document order, ready for buildTree
+func spliceSections(included []*spec.Section, parentLevel int) []*spec.Section {
+	var flat []*spec.Section
+	var flatten func(*spec.Section)
+	flatten = func(section *spec.Section) {
+		children := section.Children
+		section.Children = []*spec.Section{}
+		section.Parent = nil
+		flat = append(flat, section)
+		for _, child := range children {
+			flatten(child)
+		}
+	}
+
+	for _, root := range included {
+		adjustSectionLevels(root, parentLevel+1)
+		flatten(root)
+	}
+	return flat
+}
+
+// collectIncludes records the spec files under rootPath that are included by
+// another file, so they are not also loaded on their own
+func (f *specFiles) collectIncludes(rootPath string) error {
+	return f.walk(rootPath, func(path string) error {
+		if filepath.Ext(path) == gherkinExtension {
+			return nil
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		context := &includeContext{path: path}
+		for _, block := range scanBlocks(string(content)) {
+			if block.Include == "" {
+				continue
+			}
+			if absolute, err := filepath.Abs(context.resolve(block.Include)); err == nil {
+				f.included[absolute] = true
+			}
+		}
+		return nil
+	})
+}
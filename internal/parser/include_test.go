@@ -0,0 +1,111 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// writeSpecFiles creates files relative to dir
+func writeSpecFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestParseFileIncludes(t *testing.T) {
+	dir := t.TempDir()
+	writeSpecFiles(t, dir, map[string]string{
+		"services/billing.md": "# Billing\n\n" +
+			"## Security\n" +
+			"<!-- align:include ../shared/auth.md -->\n\n" +
+			"## Invoices\n" +
+			"**Test:** `TestInvoices`\n",
+		"shared/auth.md": "# Authentication\n\n" +
+			"## Reject expired tokens\n" +
+			"**Test:** `TestExpiredTokens`\n",
+	})
+
+	specification, err := ParseFile(filepath.Join(dir, "services", "billing.md"))
+	assert.NoError(t, err)
+
+	billing := specification.Sections[0]
+	assert.Len(t, billing.Children, 2)
+	security := billing.Children[0]
+	assert.Equal(t, "Security", security.Title)
+	assert.Len(t, security.Children, 1, "included sections are spliced in below the current heading")
+
+	auth := security.Children[0]
+	assert.Equal(t, "Authentication", auth.Title)
+	assert.Equal(t, 3, auth.Level)
+	assert.Same(t, security, auth.Parent)
+	assert.Equal(t, 4, auth.Children[0].Level)
+	assert.Equal(t, "TestExpiredTokens", auth.Children[0].TestName)
+	assert.Equal(t, filepath.Join(dir, "services", "..", "shared", "auth.md"), auth.Children[0].FilePath, "included sections keep their own file")
+	assert.Equal(t, 3, auth.Children[0].Line)
+
+	invoices := billing.Children[1]
+	assert.Equal(t, "Invoices", invoices.Title)
+	assert.Equal(t, filepath.Join(dir, "services", "billing.md"), invoices.FilePath)
+}
+
+func TestParseIncludeErrors(t *testing.T) {
+	t.Run("include cycles are detected", func(t *testing.T) {
+		dir := t.TempDir()
+		writeSpecFiles(t, dir, map[string]string{
+			"a.md": "# A\n<!-- align:include b.md -->\n",
+			"b.md": "# B\n<!-- align:include a.md -->\n",
+		})
+
+		_, err := ParseFile(filepath.Join(dir, "a.md"))
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "include cycle: ")
+		assert.True(t, strings.HasPrefix(err.Error(), filepath.Join(dir, "b.md")+":2: "), err.Error())
+	})
+
+	t.Run("missing files are reported with the directive location", func(t *testing.T) {
+		dir := t.TempDir()
+		writeSpecFiles(t, dir, map[string]string{
+			"a.md": "# A\n\n<!-- align:include missing.md -->\n",
+		})
+
+		_, err := ParseFile(filepath.Join(dir, "a.md"))
+		assert.EqualError(t, err, filepath.Join(dir, "a.md")+":3: cannot include missing.md: file not found")
+	})
+
+	t.Run("directives in code blocks are ignored", func(t *testing.T) {
+		specification, err := ParseMarkdown("# A\n\n```markdown\n<!-- align:include missing.md -->\n```\n")
+		assert.NoError(t, err)
+		assert.Len(t, specification.Sections, 1)
+	})
+}
+
+func TestParseDirectorySkipsIncludedFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeSpecFiles(t, dir, map[string]string{
+		"billing.md":     "# Billing\n<!-- align:include shared/auth.md -->\n",
+		"shipping.md":    "# Shipping\n<!-- align:include shared/auth.md -->\n",
+		"shared/auth.md": "# Authentication\n\n## Reject expired tokens\n",
+	})
+
+	specification, err := ParseDirectory(dir)
+	assert.NoError(t, err)
+
+	var titles []string
+	for _, section := range specification.Sections {
+		titles = append(titles, section.Title)
+	}
+	assert.Equal(t, []string{"Billing", "Shipping"}, titles, "the shared file only appears where it is included")
+	assert.Equal(t, "Authentication", specification.Sections[0].Children[0].Title)
+	assert.Equal(t, "Authentication", specification.Sections[1].Children[0].Title)
+}
@@ -0,0 +1,125 @@
+package parser
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Alge/aligned/internal/spec"
+)
+
+// orderFileName is the optional file listing the order of a directory's entries
+const orderFileName = "_order"
+
+// numericPrefixPattern matches an ordering prefix such as "01_" or "2-"
+var numericPrefixPattern = regexp.MustCompile(`^(\d+)[_\-. ]+(.+)$`)
+
+// directoryEntry is a file or subdirectory whose sections are placed in the tree
+type directoryEntry struct {
+	name     string // File or directory name as found on disk
+	isDir    bool
+	order    *int // Position from a numeric prefix or front-matter order, nil if unordered
+	sections []*spec.Section
+}
+
+// stripNumericPrefix removes an ordering prefix such as "01_" from a file or
+// directory name, returning the remaining name and the prefix number
+func stripNumericPrefix(name string) (string, *int) {
+	match := numericPrefixPattern.FindStringSubmatch(name)
+	if match == nil {
+		return name, nil
+	}
+	number, err := strconv.Atoi(match[1])
+	if err != nil {
+		return name, nil
+	}
+	return match[2], &number
+}
+
+// readOrderFile returns the entry names listed in a directory's _order file,
+// one per line, ignoring blank lines and # comments. Returns nil if there is none.
+func readOrderFile(dirPath string) ([]string, error) {
+	file, err := os.Open(filepath.Join(dirPath, orderFileName))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var names []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		names = append(names, line)
+	}
+	return names, scanner.Err()
+}
+
+// matchesName returns true if an _order line names the entry. The line may
+// leave out the numeric prefix and the file extension.
+func (e directoryEntry) matchesName(name string) bool {
+	name = strings.TrimSuffix(name, "/")
+	stripped, _ := stripNumericPrefix(e.name)
+	for _, candidate := range []string{e.name, stripped} {
+		if name == candidate || (!e.isDir && name == strings.TrimSuffix(candidate, filepath.Ext(candidate))) {
+			return true
+		}
+	}
+	return false
+}
+
+// sortDirectoryEntries orders the entries of a directory. Entries listed in the
+// _order file come first in the listed order, followed by entries with a numeric
+// prefix or front-matter order, lowest first. The remaining entries keep the
+// default order: files before subdirectories, alphabetically.
+func sortDirectoryEntries(dirPath string, entries []directoryEntry, listed []string) error {
+	position := make(map[string]int)
+	for i, name := range listed {
+		found := false
+		for _, entry := range entries {
+			if entry.matchesName(name) {
+				position[entry.name] = i
+				found = true
+			}
+		}
+		if !found {
+			return fmt.Errorf("%s: no file or directory named '%s'", filepath.Join(dirPath, orderFileName), name)
+		}
+	}
+
+	rank := func(entry directoryEntry) (int, int) {
+		if i, ok := position[entry.name]; ok {
+			return 0, i
+		}
+		if entry.order != nil {
+			return 1, *entry.order
+		}
+		if entry.isDir {
+			return 2, 1
+		}
+		return 2, 0
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		groupI, valueI := rank(entries[i])
+		groupJ, valueJ := rank(entries[j])
+		if groupI != groupJ {
+			return groupI < groupJ
+		}
+		if valueI != valueJ {
+			return valueI < valueJ
+		}
+		return entries[i].name < entries[j].name
+	})
+	return nil
+}
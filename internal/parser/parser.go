@@ -2,68 +2,249 @@
 package parser
 
 import (
-	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/Alge/aligned/internal/spec"
 )
 
-// ParseMarkdown parses markdown content into a Specification
+// ParseMarkdown parses markdown content into a Specification.
+// Paths in include directives are resolved relative to the working directory.
 func ParseMarkdown(content string) (*spec.Specification, error) {
-	scanner := bufio.NewScanner(strings.NewReader(content))
+	return parseMarkdown(content, &includeContext{})
+}
+
+func parseMarkdown(content string, context *includeContext) (*spec.Specification, error) {
+	metadata, content, frontMatterLines, err := ExtractFrontMatter(content)
+	if err != nil {
+		if context.path != "" {
+			return nil, fmt.Errorf("%s: %w", context.path, err)
+		}
+		return nil, err
+	}
 
 	var sections []*spec.Section
-	var currentContent strings.Builder
 	var lastSection *spec.Section
+	items := make(map[*spec.Section][]*spec.Section) // Leaves from a section's task list items, tables and examples
+	var contentLines []string                        // Everything between the last heading and the next
+	var visibleLines []string                        // The same, without code blocks and HTML comments
+	var visibleNumbers []int                         // Line of each of visibleLines
+	testLine := 0                                    // Line of the first test reference in the current section
+	var table []string                               // Consecutive visible lines that may form a table
+	tableLine := 0                                   // Line of the first of them
+	var tables []examplesTable                       // Other tables in the current section
 
-	headingPattern := regexp.MustCompile(`^(#{1,6})\s+(.+)$`)
-
-	for scanner.Scan() {
-		line := scanner.Text()
+	// flushTable turns a requirement table into sections below the current
+	// heading, or keeps any other table as ordinary content
+	flushTable := func() {
+		if len(table) == 0 {
+			return
+		}
+		if rows := ExtractRequirementTable(table, tableLine); rows != nil {
+			items[lastSection] = append(items[lastSection], rows...)
+		} else {
+			for i, text := range table {
+				visibleLines = append(visibleLines, text)
+				visibleNumbers = append(visibleNumbers, tableLine+i)
+				if testLine == 0 && isTestReferenceLine(text) {
+					testLine = tableLine + i
+				}
+			}
+			tables = append(tables, examplesTable{lines: table, firstLine: tableLine})
+		}
+		table = nil
+	}
 
-		// Check if this line is a heading
-		if matches := headingPattern.FindStringSubmatch(line); matches != nil {
-			// Save content to previous section if exists
-			if lastSection != nil {
-				lastSection.Content = strings.TrimSpace(currentContent.String())
-				lastSection.TestName = ExtractTestReference(lastSection.Content)
+	finishSection := func() {
+		if lastSection != nil {
+			lastSection.Content = strings.TrimSpace(strings.Join(contentLines, "\n"))
+			applyTestReferences(lastSection, strings.Join(visibleLines, "\n"))
+			if lastSection.HasTest() {
+				lastSection.TestLine = testLine
+				lastSection.TestLines = referenceLines(lastSection.TestNames, visibleLines, visibleNumbers)
 			}
+			// Templated test references expand into one requirement per example
+			items[lastSection] = append(items[lastSection], expandExamples(lastSection, tables)...)
+		}
+	}
+
+	for _, block := range scanBlocks(content) {
+		// Line numbers refer to the original file, including front matter
+		line := block.Line + frontMatterLines
 
-			// Create new section
-			level := len(matches[1]) // Count # symbols
-			title := strings.TrimSpace(matches[2])
+		inTable := !block.Heading && block.Visible && lastSection != nil && isTableRow(block.Text)
+		if !inTable {
+			flushTable()
+		}
 
-			section := &spec.Section{
-				Level:    level,
-				Title:    title,
-				Children: []*spec.Section{},
+		if block.Include != "" {
+			// Splice the included file's sections in below the current heading
+			included, err := context.include(block.Include, line)
+			if err != nil {
+				return nil, err
 			}
+			parentLevel := 0
+			if lastSection != nil {
+				parentLevel = lastSection.Level
+			}
+			sections = append(sections, spliceSections(included.Sections, parentLevel)...)
+		}
 
-			sections = append(sections, section)
-			lastSection = section
-			currentContent.Reset()
-		} else {
+		if !block.Heading {
 			// Accumulate content for current section
-			currentContent.WriteString(line)
-			currentContent.WriteString("\n")
+			contentLines = append(contentLines, block.Text)
+			if block.Visible && lastSection != nil {
+				// Task list items are requirements of their own, below the current heading
+				if task := ExtractTaskItem(block.Text); task != nil {
+					task.Line = line
+					if task.HasTest() {
+						task.TestLine = line
+					}
+					items[lastSection] = append(items[lastSection], task)
+					continue
+				}
+			}
+			if inTable {
+				if len(table) == 0 {
+					tableLine = line
+				}
+				table = append(table, block.Text)
+				continue
+			}
+			if block.Visible {
+				visibleLines = append(visibleLines, block.Text)
+				visibleNumbers = append(visibleNumbers, line)
+				if testLine == 0 && isTestReferenceLine(block.Text) {
+					testLine = line
+				}
+			}
+			continue
 		}
+
+		// Save content to previous section if exists
+		finishSection()
+
+		// Create new section
+		title, id := ExtractRequirementID(block.Title)
+
+		section := &spec.Section{
+			Level:    block.Level,
+			Title:    title,
+			ID:       id,
+			Line:     line,
+			Children: []*spec.Section{},
+		}
+
+		sections = append(sections, section)
+		lastSection = section
+		contentLines = nil
+		visibleLines = nil
+		visibleNumbers = nil
+		testLine = 0
+		tables = nil
 	}
 
 	// Don't forget the last section
-	if lastSection != nil {
-		lastSection.Content = strings.TrimSpace(currentContent.String())
-		lastSection.TestName = ExtractTestReference(lastSection.Content)
-	}
+	flushTable()
+	finishSection()
 
 	// Build tree structure from flat list
 	tree := buildTree(sections)
+	attachItems(items)
+	applyMetadata(tree, metadata)
 
 	return &spec.Specification{
+		Metadata: metadata,
 		Sections: tree,
 	}, nil
 }
 
+// ParseFile reads and parses a markdown specification file, or a Gherkin
+// .feature file, recording the file path on the specification and on every
+// section. Paths in include directives are resolved relative to the file.
+func ParseFile(path string) (*spec.Specification, error) {
+	return parseFile(path, nil)
+}
+
+// parseFile parses a file included from the files in stack
+func parseFile(path string, stack []string) (*spec.Specification, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var specification *spec.Specification
+	if filepath.Ext(path) == gherkinExtension {
+		specification, err = ParseGherkin(string(content))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	} else {
+		context := &includeContext{path: path, stack: append(append([]string{}, stack...), path)}
+		specification, err = parseMarkdown(string(content), context)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	specification.FilePath = path
+	specification.RootDir = filepath.Dir(path)
+	setFilePath(specification.Sections, path)
+	return specification, nil
+}
+
+// setFilePath records the source file on sections and all their descendants.
+// Sections spliced in from included files keep their own file.
+func setFilePath(sections []*spec.Section, path string) {
+	for _, section := range sections {
+		if section.FilePath == "" {
+			section.FilePath = path
+		}
+		setFilePath(section.Children, path)
+	}
+}
+
+// attachItems makes the leaves created from a section's content, such as its
+// task list items, requirement table rows and examples, the first children of
+// that section. They are attached after
+// buildTree, which would nest a later heading that skips a level below them.
+func attachItems(items map[*spec.Section][]*spec.Section) {
+	for parent, leaves := range items {
+		if len(leaves) == 0 {
+			continue
+		}
+		for _, leaf := range leaves {
+			leaf.Level = parent.Level + 1
+			leaf.Parent = parent
+		}
+		parent.Children = append(append([]*spec.Section{}, leaves...), parent.Children...)
+		parent.HasItems = true
+	}
+}
+
+// referenceLines returns the line of each test reference, found by searching
+// the section's visible lines in order for the backticked name. Unknown lines are 0.
+func referenceLines(tests []string, lines []string, numbers []int) []int {
+	var result []int
+	next := 0
+	for _, test := range tests {
+		found := 0
+		for i := next; i < len(lines); i++ {
+			if strings.Contains(lines[i], "`"+test+"`") {
+				found = numbers[i]
+				next = i // Several references may share a line
+				break
+			}
+		}
+		result = append(result, found)
+	}
+	return result
+}
+
 // buildTree converts a flat list of sections into a hierarchical tree
 func buildTree(sections []*spec.Section) []*spec.Section {
 	if len(sections) == 0 {
@@ -96,6 +277,155 @@ func buildTree(sections []*spec.Section) []*spec.Section {
 	return roots
 }
 
+// requirementIDPattern matches a {#PARSER-012} marker in a heading
+var requirementIDPattern = regexp.MustCompile(`\s*\{#([A-Za-z0-9][A-Za-z0-9_.:-]*)\}`)
+
+// ExtractRequirementID removes a {#ID} marker from a heading title.
+// Returns the title without the marker and the ID (empty if there is none).
+func ExtractRequirementID(title string) (string, string) {
+	matches := requirementIDPattern.FindStringSubmatch(title)
+	if matches == nil {
+		return title, ""
+	}
+
+	stripped := requirementIDPattern.ReplaceAllString(title, " ")
+	return strings.Join(strings.Fields(stripped), " "), matches[1]
+}
+
+// justificationPattern matches the **Justification:** line of a [MANUAL] section
+var justificationPattern = regexp.MustCompile(`(?m)^\*\*[Jj]ustification:\*\*[ \t]*(.*)$`)
+
+// ExtractJustification returns the text of a **Justification:** line, or an empty string
+func ExtractJustification(content string) string {
+	matches := justificationPattern.FindStringSubmatch(content)
+	if matches == nil {
+		return ""
+	}
+	return strings.TrimSpace(matches[1])
+}
+
+// reasonPattern matches the **Reason:** line of an [N/A] section
+var reasonPattern = regexp.MustCompile(`(?m)^\*\*[Rr]eason:\*\*[ \t]*(.*)$`)
+
+// ExtractReason returns the text of a **Reason:** line, or an empty string
+func ExtractReason(content string) string {
+	matches := reasonPattern.FindStringSubmatch(content)
+	if matches == nil {
+		return ""
+	}
+	return strings.TrimSpace(matches[1])
+}
+
+// testPatternPattern matches the **Test pattern:** line of an interface section
+var testPatternPattern = regexp.MustCompile("(?m)^\\*\\*[Tt]est pattern:\\*\\*[ \\t]*`([^`]+)`")
+
+// ExtractTestPattern returns the backticked pattern of a **Test pattern:** line,
+// or an empty string
+func ExtractTestPattern(content string) string {
+	matches := testPatternPattern.FindStringSubmatch(content)
+	if matches == nil {
+		return ""
+	}
+	return strings.TrimSpace(matches[1])
+}
+
+// testPrefixPattern matches a **Test prefix:** line
+var testPrefixPattern = regexp.MustCompile("(?m)^\\*\\*[Tt]est prefix:\\*\\*[ \\t]*`([^`]+)`")
+
+// ExtractTestPrefix returns the backticked prefix of a **Test prefix:** line,
+// or an empty string
+func ExtractTestPrefix(content string) string {
+	matches := testPrefixPattern.FindStringSubmatch(content)
+	if matches == nil {
+		return ""
+	}
+	return strings.TrimSpace(matches[1])
+}
+
+// minMatchesPattern matches a **Min matches:** line
+var minMatchesPattern = regexp.MustCompile(`(?m)^\*\*[Mm]in matches:\*\*[ \t]*(\d+)[ \t]*$`)
+
+// ExtractMinMatches returns the number on a **Min matches:** line, or 0 if there is none
+func ExtractMinMatches(content string) int {
+	matches := minMatchesPattern.FindStringSubmatch(content)
+	if matches == nil {
+		return 0
+	}
+	count, err := strconv.Atoi(matches[1])
+	if err != nil {
+		return 0
+	}
+	return count
+}
+
+// tagsPattern matches a **Tags:** line
+var tagsPattern = regexp.MustCompile(`(?m)^\*\*[Tt]ags:\*\*[ \t]*(.*)$`)
+
+// ExtractTags returns the comma-separated tags from a **Tags:** line.
+// Tags may optionally be wrapped in backticks.
+func ExtractTags(content string) []string {
+	matches := tagsPattern.FindStringSubmatch(content)
+	if matches == nil {
+		return nil
+	}
+	return splitList(strings.ReplaceAll(matches[1], "`", ""))
+}
+
+// taskItemPattern matches a "- [ ] text" task list item
+var taskItemPattern = regexp.MustCompile(`^ {0,3}[-*+][ \t]+\[[ xX]\][ \t]+(.+)$`)
+
+// trailingReferencesPattern splits task item text from the backticked test
+// references at its end
+var trailingReferencesPattern = regexp.MustCompile("^(.*?)((?:[\\s,:\u2013\u2014-]*`[^`]+`)+)\\s*$")
+
+// ExtractTaskItem returns a leaf section for a "- [ ] Rejects empty password `TestRejectEmpty`"
+// task list item, with the backticked references at the end of the item as its
+// tests. Returns nil if the line is not a task list item. Whether the box is
+// ticked does not matter.
+func ExtractTaskItem(line string) *spec.Section {
+	matches := taskItemPattern.FindStringSubmatch(line)
+	if matches == nil {
+		return nil
+	}
+
+	text := strings.TrimSpace(matches[1])
+	var tests []string
+	if parts := trailingReferencesPattern.FindStringSubmatch(text); parts != nil && strings.TrimSpace(parts[1]) != "" {
+		text = strings.TrimRight(parts[1], " \t,:\u2013\u2014-")
+		for _, m := range backtickPattern.FindAllStringSubmatch(parts[2], -1) {
+			tests = append(tests, m[1])
+		}
+	}
+
+	title, id := ExtractRequirementID(text)
+	section := &spec.Section{
+		Title:     title,
+		ID:        id,
+		TestNames: tests,
+		Children:  []*spec.Section{},
+	}
+	if len(tests) > 0 {
+		section.TestName = tests[0]
+	}
+	return section
+}
+
+// applyTestReferences fills in the test reference fields of a section from the
+// visible part of its content, so examples in code blocks and comments are ignored
+func applyTestReferences(section *spec.Section, visible string) {
+	section.Justification = ExtractJustification(visible)
+	section.Reason = ExtractReason(visible)
+	section.TestPattern = ExtractTestPattern(visible)
+	section.TestPrefix = ExtractTestPrefix(visible)
+	section.Tags = ExtractTags(visible)
+	section.TestNames = ExtractTestReferences(visible)
+	section.TestMatch = ExtractTestMatch(visible)
+	section.MinMatches = ExtractMinMatches(visible)
+	if len(section.TestNames) > 0 {
+		section.TestName = section.TestNames[0]
+	}
+}
+
 // ExtractTestReference finds and extracts the test name from content
 // Looks for pattern: **Test:** `TestName`
 // Returns empty string if no test reference found
@@ -108,12 +438,87 @@ func ExtractTestReference(content string) string {
 	// - ExUnit: test/file.exs:Module:test description with spaces
 	// - Gleam: module@submodule.function_name_test
 	// - Vitest: src/file.test.js > describe > test name
-	pattern := regexp.MustCompile("(?m)^\\*\\*[Tt]est:\\*\\*\\s*`([^`]+)`")
-
-	matches := pattern.FindStringSubmatch(content)
-	if len(matches) > 1 {
-		return matches[1]
+	references := ExtractTestReferences(content)
+	if len(references) > 0 {
+		return references[0]
 	}
 
 	return ""
-}
\ No newline at end of file
+}
+
+var (
+	// **Test:** `name` - a single reference, may be repeated on several lines
+	testLinePattern = regexp.MustCompile("^\\*\\*[Tt]est:\\*\\*\\s*`([^`]+)`")
+	// **Tests:** `a`, `b` or **Tests (any of):** followed by a list
+	testsLinePattern = regexp.MustCompile(`^\*\*[Tt]ests(?:\s*\((any|all)(?: of)?\))?:\*\*\s*(.*)$`)
+	// - `name` list item below a **Tests:** line
+	testListItemPattern = regexp.MustCompile("^\\s*[-*+]\\s+`([^`]+)`")
+	backtickPattern     = regexp.MustCompile("`([^`]+)`")
+)
+
+// isTestReferenceLine returns true if the line declares one or more test references
+func isTestReferenceLine(line string) bool {
+	return testLinePattern.MatchString(line) || testsLinePattern.MatchString(line)
+}
+
+// ExtractTestReferences finds all test references in content, in order of appearance.
+// Supports repeated **Test:** lines as well as a **Tests:** line followed either by
+// backticked names on the same line or by a markdown list of backticked names.
+// Duplicate references are only returned once.
+func ExtractTestReferences(content string) []string {
+	var references []string
+	seen := make(map[string]bool)
+	add := func(name string) {
+		if !seen[name] {
+			seen[name] = true
+			references = append(references, name)
+		}
+	}
+
+	lines := strings.Split(content, "\n")
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+
+		if matches := testLinePattern.FindStringSubmatch(line); matches != nil {
+			add(matches[1])
+			continue
+		}
+
+		matches := testsLinePattern.FindStringSubmatch(line)
+		if matches == nil {
+			continue
+		}
+
+		inline := backtickPattern.FindAllStringSubmatch(matches[2], -1)
+		for _, m := range inline {
+			add(m[1])
+		}
+		if len(inline) > 0 {
+			continue
+		}
+
+		// No names on the same line - collect the list that follows
+		for i+1 < len(lines) {
+			item := testListItemPattern.FindStringSubmatch(lines[i+1])
+			if item == nil {
+				break
+			}
+			add(item[1])
+			i++
+		}
+	}
+
+	return references
+}
+
+// ExtractTestMatch returns how the test references in content should be evaluated.
+// A **Tests (any of):** line makes a single discovered test enough; everything
+// else requires all referenced tests to exist.
+func ExtractTestMatch(content string) spec.TestMatch {
+	for _, line := range strings.Split(content, "\n") {
+		if matches := testsLinePattern.FindStringSubmatch(line); matches != nil && matches[1] == "any" {
+			return spec.MatchAny
+		}
+	}
+	return spec.MatchAll
+}
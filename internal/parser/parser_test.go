@@ -2,7 +2,13 @@
 package parser
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+
+	"github.com/Alge/aligned/internal/spec"
 )
 
 func TestParseMarkdownHeadings(t *testing.T) {
@@ -145,4 +151,580 @@ More text after.`,
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestExtractTestReferences(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{
+			name:     "single test reference",
+			input:    "**Test:** `TestSomething`",
+			expected: []string{"TestSomething"},
+		},
+		{
+			name: "repeated test lines",
+			input: "**Test:** `pkg.TestUnit`\n" +
+				"**Test:** `pkg.TestIntegration`",
+			expected: []string{"pkg.TestUnit", "pkg.TestIntegration"},
+		},
+		{
+			name:     "inline tests list",
+			input:    "**Tests:** `pkg.TestUnit`, `pkg.TestIntegration`",
+			expected: []string{"pkg.TestUnit", "pkg.TestIntegration"},
+		},
+		{
+			name: "tests followed by markdown list",
+			input: `Some description.
+
+**Tests:**
+- ` + "`pkg.TestUnit`" + `
+- ` + "`tests/test_api.py::test_login`" + `
+
+More text after.`,
+			expected: []string{"pkg.TestUnit", "tests/test_api.py::test_login"},
+		},
+		{
+			name:     "any of list",
+			input:    "**Tests (any of):** `TestA`, `TestB`",
+			expected: []string{"TestA", "TestB"},
+		},
+		{
+			name: "duplicates are only returned once",
+			input: "**Test:** `TestA`\n" +
+				"**Tests:** `TestA`, `TestB`",
+			expected: []string{"TestA", "TestB"},
+		},
+		{
+			name:     "no test reference",
+			input:    "Just regular content without a test.",
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ExtractTestReferences(tt.input)
+			if len(result) != len(tt.expected) {
+				t.Fatalf("ExtractTestReferences() = %q, want %q", result, tt.expected)
+			}
+			for i := range result {
+				if result[i] != tt.expected[i] {
+					t.Errorf("ExtractTestReferences()[%d] = %q, want %q", i, result[i], tt.expected[i])
+				}
+			}
+		})
+	}
+}
+
+func TestExtractTestMatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected spec.TestMatch
+	}{
+		{
+			name:     "single test defaults to all",
+			input:    "**Test:** `TestA`",
+			expected: spec.MatchAll,
+		},
+		{
+			name:     "tests list defaults to all",
+			input:    "**Tests:** `TestA`, `TestB`",
+			expected: spec.MatchAll,
+		},
+		{
+			name:     "explicit all of",
+			input:    "**Tests (all of):** `TestA`, `TestB`",
+			expected: spec.MatchAll,
+		},
+		{
+			name:     "any of",
+			input:    "**Tests (any of):** `TestA`, `TestB`",
+			expected: spec.MatchAny,
+		},
+		{
+			name:     "short any",
+			input:    "**Tests (any):**\n- `TestA`\n- `TestB`",
+			expected: spec.MatchAny,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ExtractTestMatch(tt.input)
+			if result != tt.expected {
+				t.Errorf("ExtractTestMatch() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestExtractRequirementID(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         string
+		expectedTitle string
+		expectedID    string
+	}{
+		{
+			name:          "id at end of heading",
+			input:         "Parse headings {#PARSER-012}",
+			expectedTitle: "Parse headings",
+			expectedID:    "PARSER-012",
+		},
+		{
+			name:          "id before other markers",
+			input:         "Connector {#CONN-1} [INTERFACE]",
+			expectedTitle: "Connector [INTERFACE]",
+			expectedID:    "CONN-1",
+		},
+		{
+			name:          "no id",
+			input:         "Parse headings",
+			expectedTitle: "Parse headings",
+			expectedID:    "",
+		},
+		{
+			name:          "braces without hash are not an id",
+			input:         "Expand {case} templates",
+			expectedTitle: "Expand {case} templates",
+			expectedID:    "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			title, id := ExtractRequirementID(tt.input)
+			if title != tt.expectedTitle {
+				t.Errorf("ExtractRequirementID() title = %q, want %q", title, tt.expectedTitle)
+			}
+			if id != tt.expectedID {
+				t.Errorf("ExtractRequirementID() id = %q, want %q", id, tt.expectedID)
+			}
+		})
+	}
+
+	t.Run("parsed headings carry the id", func(t *testing.T) {
+		result, err := ParseMarkdown("# Parser\n\n## Parse headings {#PARSER-012}\n")
+		if err != nil {
+			t.Fatalf("ParseMarkdown() error = %v", err)
+		}
+		child := result.Sections[0].Children[0]
+		if child.ID != "PARSER-012" {
+			t.Errorf("child.ID = %q, want %q", child.ID, "PARSER-012")
+		}
+		if child.Title != "Parse headings" {
+			t.Errorf("child.Title = %q, want %q", child.Title, "Parse headings")
+		}
+	})
+}
+
+func TestExtractJustification(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "justification line",
+			input:    "Checked by QA.\n\n**Justification:** Verified during release sign-off\n",
+			expected: "Verified during release sign-off",
+		},
+		{
+			name:     "empty justification",
+			input:    "**Justification:**",
+			expected: "",
+		},
+		{
+			name:     "no justification",
+			input:    "**Test:** `TestSomething`",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ExtractJustification(tt.input)
+			if result != tt.expected {
+				t.Errorf("ExtractJustification() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestExtractReason(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "reason line",
+			input:    "Waived.\n\n**Reason:** Gleam has no notion of an empty test suite\n",
+			expected: "Gleam has no notion of an empty test suite",
+		},
+		{
+			name:     "lowercase label",
+			input:    "**reason:** Not supported",
+			expected: "Not supported",
+		},
+		{
+			name:     "no reason",
+			input:    "**Justification:** Checked by hand",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ExtractReason(tt.input)
+			if result != tt.expected {
+				t.Errorf("ExtractReason() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestExtractTestPattern(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "pattern line",
+			input:    "Every connector is tested the same way.\n\n**Test pattern:** `{impl}_test.Test{Section}`\n",
+			expected: "{impl}_test.Test{Section}",
+		},
+		{
+			name:     "not a test reference",
+			input:    "**Test pattern:** `{impl}_test.Test{Section}`",
+			expected: "{impl}_test.Test{Section}",
+		},
+		{
+			name:     "no pattern",
+			input:    "**Test:** `TestSomething`",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ExtractTestPattern(tt.input)
+			if result != tt.expected {
+				t.Errorf("ExtractTestPattern() = %q, want %q", result, tt.expected)
+			}
+			if tt.expected != "" && len(ExtractTestReferences(tt.input)) != 0 {
+				t.Errorf("a test pattern should not be read as a test reference")
+			}
+		})
+	}
+}
+
+func TestExtractTestPrefix(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "prefix line",
+			input:    "Tests live in the parser package.\n\n**Test prefix:** `Alge/aligned/internal/parser.`\n",
+			expected: "Alge/aligned/internal/parser.",
+		},
+		{
+			name:     "no prefix",
+			input:    "**Test:** `TestSomething`",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ExtractTestPrefix(tt.input)
+			if result != tt.expected {
+				t.Errorf("ExtractTestPrefix() = %q, want %q", result, tt.expected)
+			}
+			if tt.expected != "" && len(ExtractTestReferences(tt.input)) != 0 {
+				t.Errorf("a test prefix should not be read as a test reference")
+			}
+		})
+	}
+}
+
+func TestExtractMinMatches(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected int
+	}{
+		{
+			name:     "min matches line",
+			input:    "**Test:** `parser.TestParse*`\n**Min matches:** 3\n",
+			expected: 3,
+		},
+		{
+			name:     "not a number",
+			input:    "**Min matches:** many",
+			expected: 0,
+		},
+		{
+			name:     "no line",
+			input:    "**Test:** `parser.TestParse*`",
+			expected: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := ExtractMinMatches(tt.input); result != tt.expected {
+				t.Errorf("ExtractMinMatches() = %d, want %d", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestExtractTags(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{
+			name:     "comma separated tags",
+			input:    "Description.\n\n**Tags:** security, api\n",
+			expected: []string{"security", "api"},
+		},
+		{
+			name:     "backticked tags",
+			input:    "**Tags:** `security`, `api`",
+			expected: []string{"security", "api"},
+		},
+		{
+			name:     "no tags",
+			input:    "**Test:** `TestSomething`",
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ExtractTags(tt.input)
+			if len(result) != len(tt.expected) {
+				t.Fatalf("ExtractTags() = %q, want %q", result, tt.expected)
+			}
+			for i := range result {
+				if result[i] != tt.expected[i] {
+					t.Errorf("ExtractTags()[%d] = %q, want %q", i, result[i], tt.expected[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParseMarkdownIgnoresCodeAndComments(t *testing.T) {
+	input := "# Install\n\n" +
+		"Run the installer:\n\n" +
+		"```bash\n" +
+		"# download the binary\n" +
+		"**Test:** `TestInsideCode`\n" +
+		"```\n\n" +
+		"<!-- **Test:** `TestInsideComment` -->\n\n" +
+		"**Test:** `TestInstall`\n"
+
+	result, err := ParseMarkdown(input)
+	if err != nil {
+		t.Fatalf("ParseMarkdown() error = %v", err)
+	}
+
+	if len(result.Sections) != 1 || len(result.Sections[0].Children) != 0 {
+		t.Fatalf("shell comment in code block created a phantom section")
+	}
+	section := result.Sections[0]
+	if len(section.Tests()) != 1 || section.TestName != "TestInstall" {
+		t.Errorf("Tests() = %q, want only %q", section.Tests(), "TestInstall")
+	}
+	if !strings.Contains(section.Content, "# download the binary") {
+		t.Errorf("code blocks should be kept in Content")
+	}
+}
+
+func TestParseMarkdownSourceLines(t *testing.T) {
+	input := "---\n" +
+		"owner: parser-team\n" +
+		"---\n" +
+		"# Parser\n" +
+		"\n" +
+		"## Headings\n" +
+		"Detects headings.\n" +
+		"\n" +
+		"**Test:** `TestHeadings`\n" +
+		"\n" +
+		"## Untested\n" +
+		"\n" +
+		"## Lists\n" +
+		"**Test:** `TestLists`\n" +
+		"**Tests:**\n" +
+		"- `TestNestedLists`\n" +
+		"- `TestEmptyLists`\n"
+
+	result, err := ParseMarkdown(input)
+	if err != nil {
+		t.Fatalf("ParseMarkdown() error = %v", err)
+	}
+
+	root := result.Sections[0]
+	if root.Line != 4 {
+		t.Errorf("root Line = %d, want 4 (front matter lines count)", root.Line)
+	}
+	headings := root.Children[0]
+	if headings.Line != 6 || headings.TestLine != 9 {
+		t.Errorf("Headings Line, TestLine = %d, %d, want 6, 9", headings.Line, headings.TestLine)
+	}
+	untested := root.Children[1]
+	if untested.Line != 11 || untested.TestLine != 0 {
+		t.Errorf("Untested Line, TestLine = %d, %d, want 11, 0", untested.Line, untested.TestLine)
+	}
+	lists := root.Children[2]
+	if got, want := fmt.Sprint(lists.TestLines), "[14 16 17]"; got != want {
+		t.Errorf("Lists TestLines = %s, want %s", got, want)
+	}
+}
+
+func TestParseFileRecordsPath(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "parser.md")
+	if err := os.WriteFile(path, []byte("# Parser\n\n## Headings\n**Test:** `TestHeadings`\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := ParseFile(path)
+	if err != nil {
+		t.Fatalf("ParseFile() error = %v", err)
+	}
+
+	headings := result.Sections[0].Children[0]
+	if result.FilePath != path || headings.FilePath != path {
+		t.Errorf("FilePath = %q, %q, want %q", result.FilePath, headings.FilePath, path)
+	}
+	if result.RootDir != filepath.Dir(path) {
+		t.Errorf("RootDir = %q, want %q", result.RootDir, filepath.Dir(path))
+	}
+	if got, want := headings.TestLocation(), path+":4"; got != want {
+		t.Errorf("TestLocation() = %q, want %q", got, want)
+	}
+}
+
+func TestExtractTaskItem(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         string
+		expectedTitle string
+		expectedTests []string
+	}{
+		{
+			name:          "unchecked item with a test",
+			input:         "- [ ] Rejects empty password `TestRejectEmpty`",
+			expectedTitle: "Rejects empty password",
+			expectedTests: []string{"TestRejectEmpty"},
+		},
+		{
+			name:          "checked item with separated tests",
+			input:         "* [x] Locks the account: `auth.TestLockout`, `auth.TestLockoutEmail`",
+			expectedTitle: "Locks the account",
+			expectedTests: []string{"auth.TestLockout", "auth.TestLockoutEmail"},
+		},
+		{
+			name:          "inline code in the text is not a test",
+			input:         "- [ ] Rejects `null` input - `TestRejectNull`",
+			expectedTitle: "Rejects `null` input",
+			expectedTests: []string{"TestRejectNull"},
+		},
+		{
+			name:          "item without a test",
+			input:         "- [X] Shows a hint",
+			expectedTitle: "Shows a hint",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := ExtractTaskItem(tt.input)
+			if task == nil {
+				t.Fatalf("ExtractTaskItem(%q) = nil", tt.input)
+			}
+			if task.Title != tt.expectedTitle {
+				t.Errorf("Title = %q, want %q", task.Title, tt.expectedTitle)
+			}
+			if len(task.TestNames) != len(tt.expectedTests) {
+				t.Fatalf("TestNames = %q, want %q", task.TestNames, tt.expectedTests)
+			}
+			for i := range task.TestNames {
+				if task.TestNames[i] != tt.expectedTests[i] {
+					t.Errorf("TestNames[%d] = %q, want %q", i, task.TestNames[i], tt.expectedTests[i])
+				}
+			}
+		})
+	}
+
+	for _, line := range []string{"- Rejects empty password `TestRejectEmpty`", "- [] Not a task", "Text [ ] here"} {
+		if task := ExtractTaskItem(line); task != nil {
+			t.Errorf("ExtractTaskItem(%q) = %q, want nil", line, task.Title)
+		}
+	}
+}
+
+func TestParseMarkdownTaskLists(t *testing.T) {
+	content := "# Login\n\n" +
+		"## Password rules\n" +
+		"Acceptance criteria:\n\n" +
+		"- [ ] Rejects empty password `TestRejectEmpty`\n" +
+		"- [x] Rejects short password\n\n" +
+		"```markdown\n- [ ] Example only `TestExample`\n```\n\n" +
+		"## Sessions\n"
+
+	result, err := ParseMarkdown(content)
+	if err != nil {
+		t.Fatalf("ParseMarkdown() error = %v", err)
+	}
+
+	rules := result.Sections[0].Children[0]
+	if len(rules.Children) != 2 {
+		t.Fatalf("Password rules has %d children, want 2 (task items outside code blocks)", len(rules.Children))
+	}
+	empty := rules.Children[0]
+	if empty.Title != "Rejects empty password" || empty.TestName != "TestRejectEmpty" || empty.Level != 3 {
+		t.Errorf("first task = %q (%q, level %d)", empty.Title, empty.TestName, empty.Level)
+	}
+	if empty.Line != 6 || empty.TestLine != 6 || empty.Parent != rules {
+		t.Errorf("first task Line, TestLine = %d, %d, want 6, 6 below Password rules", empty.Line, empty.TestLine)
+	}
+	short := rules.Children[1]
+	if short.Title != "Rejects short password" || !short.RequiresTest() {
+		t.Errorf("second task = %q, RequiresTest() = %v, want an untested leaf", short.Title, short.RequiresTest())
+	}
+	if rules.RequiresTest() {
+		t.Error("a section with task items should not need a test of its own")
+	}
+	if len(result.Sections[0].Children) != 2 || result.Sections[0].Children[1].Title != "Sessions" {
+		t.Error("headings after the task list should keep their place")
+	}
+
+	// A heading that skips a level is not nested below the last task item
+	result, err = ParseMarkdown("# Login\n\n" +
+		"- [ ] Rejects empty password `TestA`\n" +
+		"- [ ] Rejects short password `TestB`\n\n" +
+		"### Lockout\n")
+	if err != nil {
+		t.Fatalf("ParseMarkdown() error = %v", err)
+	}
+	login := result.Sections[0]
+	if len(login.Children) != 3 || login.Children[2].Title != "Lockout" || login.Children[2].Parent != login {
+		t.Fatalf("Login children = %d, want both task items followed by Lockout", len(login.Children))
+	}
+	if short := login.Children[1]; !short.IsLeaf() || short.TestName != "TestB" {
+		t.Errorf("Rejects short password should stay a leaf referencing TestB")
+	}
+}
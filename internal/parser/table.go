@@ -0,0 +1,93 @@
+package parser
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/Alge/aligned/internal/spec"
+)
+
+// tableDelimiterPattern matches the |---|:---:| row below a table header
+var tableDelimiterPattern = regexp.MustCompile(`^\s*\|?\s*:?-+:?\s*(\|\s*:?-+:?\s*)*\|?\s*$`)
+
+// isTableRow returns true if the line could be a row of a markdown table
+func isTableRow(line string) bool {
+	return strings.Contains(line, "|") && taskItemPattern.FindStringSubmatch(line) == nil
+}
+
+// ExtractRequirementTable returns a leaf section for every row of a markdown
+// table with Requirement and Test columns, with the header on firstLine. The
+// Test column holds backticked references, or plain names separated by
+// commas, and an optional ID column sets requirement IDs. Returns nil if the
+// lines are not such a table.
+func ExtractRequirementTable(lines []string, firstLine int) []*spec.Section {
+	if len(lines) < 2 || !tableDelimiterPattern.MatchString(lines[1]) {
+		return nil
+	}
+
+	requirementColumn, testColumn, idColumn := -1, -1, -1
+	for i, cell := range splitTableRow(lines[0]) {
+		switch strings.ToLower(strings.Trim(cell, "*_ ")) {
+		case "requirement", "requirements":
+			requirementColumn = i
+		case "test", "tests":
+			testColumn = i
+		case "id":
+			idColumn = i
+		}
+	}
+	if requirementColumn < 0 || testColumn < 0 {
+		return nil
+	}
+
+	sections := []*spec.Section{}
+	for i, line := range lines[2:] {
+		cells := splitTableRow(line)
+		cell := func(column int) string {
+			if column < 0 || column >= len(cells) {
+				return ""
+			}
+			return cells[column]
+		}
+
+		title, id := ExtractRequirementID(cell(requirementColumn))
+		if idColumn >= 0 && cell(idColumn) != "" {
+			id = strings.Trim(cell(idColumn), "`")
+		}
+		if title == "" {
+			continue
+		}
+
+		section := &spec.Section{
+			Title:    title,
+			ID:       id,
+			Line:     firstLine + 2 + i,
+			Children: []*spec.Section{},
+		}
+		section.TestNames = tableTestReferences(cell(testColumn))
+		if len(section.TestNames) > 0 {
+			section.TestName = section.TestNames[0]
+			section.TestLine = section.Line
+		}
+		sections = append(sections, section)
+	}
+	return sections
+}
+
+// tableTestReferences returns the backticked references in a Test cell, or
+// the comma-separated names if nothing is backticked. A dash means no test.
+func tableTestReferences(cell string) []string {
+	var tests []string
+	for _, m := range backtickPattern.FindAllStringSubmatch(cell, -1) {
+		tests = append(tests, m[1])
+	}
+	if len(tests) > 0 {
+		return tests
+	}
+	for _, name := range splitList(cell) {
+		if strings.Trim(name, "-\u2013\u2014") != "" {
+			tests = append(tests, name)
+		}
+	}
+	return tests
+}
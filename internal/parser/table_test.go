@@ -0,0 +1,149 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExtractRequirementTable(t *testing.T) {
+	t.Run("rows become leaves", func(t *testing.T) {
+		lines := []string{
+			"| ID | Requirement | Test | Notes |",
+			"|----|-------------|:----:|-------|",
+			"| LOGIN-1 | Rejects empty password | `auth.TestRejectEmpty` | |",
+			"| | Locks after 3 attempts | `auth.TestLockout`, `auth.TestLockoutEmail` | audited |",
+			"| | Remembers the user {#LOGIN-3} | auth.TestRemember | |",
+			"| | Shows a strength hint | - | later |",
+			"| | Accepts a \\| in passwords | | |",
+		}
+
+		rows := ExtractRequirementTable(lines, 10)
+		if len(rows) != 5 {
+			t.Fatalf("ExtractRequirementTable() returned %d rows, want 5", len(rows))
+		}
+
+		expected := []struct {
+			title string
+			id    string
+			tests []string
+		}{
+			{"Rejects empty password", "LOGIN-1", []string{"auth.TestRejectEmpty"}},
+			{"Locks after 3 attempts", "", []string{"auth.TestLockout", "auth.TestLockoutEmail"}},
+			{"Remembers the user", "LOGIN-3", []string{"auth.TestRemember"}},
+			{"Shows a strength hint", "", nil},
+			{"Accepts a | in passwords", "", nil},
+		}
+		for i, want := range expected {
+			row := rows[i]
+			if row.Title != want.title || row.ID != want.id {
+				t.Errorf("row %d = %q {#%s}, want %q {#%s}", i, row.Title, row.ID, want.title, want.id)
+			}
+			if strings.Join(row.TestNames, ",") != strings.Join(want.tests, ",") {
+				t.Errorf("row %d TestNames = %q, want %q", i, row.TestNames, want.tests)
+			}
+			if row.Line != 12+i {
+				t.Errorf("row %d Line = %d, want %d", i, row.Line, 12+i)
+			}
+		}
+		if rows[0].TestLine != 12 || rows[3].TestLine != 0 {
+			t.Errorf("TestLine = %d, %d, want 12, 0", rows[0].TestLine, rows[3].TestLine)
+		}
+	})
+
+	t.Run("other tables are ignored", func(t *testing.T) {
+		tables := [][]string{
+			{"| Name | Value |", "|---|---|", "| a | `b` |"},
+			{"| Requirement | Test |", "| a | `b` |"},
+			{"Requirement | Test"},
+		}
+		for _, lines := range tables {
+			if rows := ExtractRequirementTable(lines, 1); rows != nil {
+				t.Errorf("ExtractRequirementTable(%q) = %d rows, want nil", lines, len(rows))
+			}
+		}
+	})
+}
+
+func TestParseMarkdownRequirementTables(t *testing.T) {
+	content := "# Login\n\n" +
+		"## Behavior matrix\n" +
+		"| Requirement | Test |\n" +
+		"|---|---|\n" +
+		"| Rejects empty password | `TestRejectEmpty` |\n" +
+		"| Rejects short password | `TestRejectShort` |\n\n" +
+		"| Setting | Default |\n" +
+		"|---|---|\n" +
+		"| timeout | 30s |\n\n" +
+		"## Sessions\n" +
+		"**Test:** `TestSessions`\n"
+
+	result, err := ParseMarkdown(content)
+	if err != nil {
+		t.Fatalf("ParseMarkdown() error = %v", err)
+	}
+
+	login := result.Sections[0]
+	if len(login.Children) != 2 {
+		t.Fatalf("Login has %d children, want 2", len(login.Children))
+	}
+	matrix := login.Children[0]
+	if len(matrix.Children) != 2 {
+		t.Fatalf("Behavior matrix has %d children, want 2", len(matrix.Children))
+	}
+	short := matrix.Children[1]
+	if short.Title != "Rejects short password" || short.TestName != "TestRejectShort" || short.Level != 3 || short.Line != 7 {
+		t.Errorf("second row = %q (%q, level %d, line %d)", short.Title, short.TestName, short.Level, short.Line)
+	}
+	if !strings.Contains(matrix.Content, "| timeout | 30s |") {
+		t.Error("tables without Requirement and Test columns should stay content")
+	}
+	if sessions := login.Children[1]; sessions.TestName != "TestSessions" || sessions.TestLine != 14 {
+		t.Errorf("Sessions = %q at line %d, want TestSessions at line 14", sessions.TestName, sessions.TestLine)
+	}
+}
+
+func TestParseMarkdownTableRowsBeforeSkippedLevel(t *testing.T) {
+	content := "# Login\n\n" +
+		"## Other\n" +
+		"| Requirement | Test |\n" +
+		"|-------------|------|\n" +
+		"| Rejects empty password | `TestC` |\n" +
+		"| Rejects short password | `TestD` |\n\n" +
+		"#### Deep\n\n" +
+		"## Parse numbers\n" +
+		"**Test:** `TestParse/{case}`\n\n" +
+		"| case |\n" +
+		"|------|\n" +
+		"| integer |\n\n" +
+		"#### Deeper\n"
+
+	result, err := ParseMarkdown(content)
+	if err != nil {
+		t.Fatalf("ParseMarkdown() error = %v", err)
+	}
+
+	for i, want := range []struct {
+		title    string
+		children []string
+	}{
+		{"Other", []string{"Rejects empty password", "Rejects short password", "Deep"}},
+		{"Parse numbers", []string{"integer", "Deeper"}},
+	} {
+		section := result.Sections[0].Children[i]
+		var titles []string
+		for _, child := range section.Children {
+			titles = append(titles, child.Title)
+			if child.Parent != section {
+				t.Errorf("%s has the wrong parent", child.Title)
+			}
+		}
+		if strings.Join(titles, ", ") != strings.Join(want.children, ", ") {
+			t.Errorf("%s children = %q, want %q", section.Title, titles, want.children)
+		}
+	}
+
+	short := result.Sections[0].Children[0].Children[1]
+	if !short.IsLeaf() || short.TestName != "TestD" || short.Level != 3 {
+		t.Errorf("Rejects short password should stay a level 3 leaf referencing TestD")
+	}
+}
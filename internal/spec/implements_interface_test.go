@@ -97,4 +97,39 @@ func TestExtractInterfaceName(t *testing.T) {
 			assert.Equal(t, tc.expected, result)
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestExtractMultipleInterfaceNames(t *testing.T) {
+	testCases := []struct {
+		title    string
+		expected []string
+	}{
+		{
+			title:    "Go [IMPLEMENTS: Connector]",
+			expected: []string{"Connector"},
+		},
+		{
+			title:    "Go [IMPLEMENTS: Connector, Configurable]",
+			expected: []string{"Connector", "Configurable"},
+		},
+		{
+			title:    "Go [IMPLEMENTS: Connector] [IMPLEMENTS: Configurable]",
+			expected: []string{"Connector", "Configurable"},
+		},
+		{
+			title:    "Go [IMPLEMENTS: Connector, Connector]",
+			expected: []string{"Connector"},
+		},
+		{
+			title:    "No marker here",
+			expected: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.title, func(t *testing.T) {
+			section := &Section{Title: tc.title}
+			assert.Equal(t, tc.expected, section.GetImplementedInterfaces())
+		})
+	}
+}
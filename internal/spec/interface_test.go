@@ -1,6 +1,7 @@
 package spec
 
 import (
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -51,4 +52,209 @@ func TestDetectInterfaceMarker(t *testing.T) {
 			assert.Equal(t, tt.expected, result, "IsInterface() for title '%s'", tt.title)
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestInterfaceInheritance(t *testing.T) {
+	build := func(sections ...*Section) *Specification {
+		for _, section := range sections {
+			for _, child := range section.Children {
+				child.Parent = section
+			}
+		}
+		return &Specification{Sections: sections}
+	}
+
+	t.Run("extending interface is detected and named", func(t *testing.T) {
+		section := &Section{Title: "HTTP Connector [INTERFACE EXTENDS: Connector]"}
+		assert.True(t, section.IsInterface())
+		assert.Equal(t, "HTTP Connector", section.InterfaceName())
+		assert.Equal(t, "Connector", section.GetExtendedInterface())
+	})
+
+	t.Run("implementations need inherited sections", func(t *testing.T) {
+		specification := build(
+			&Section{Title: "Connector [INTERFACE]", Children: []*Section{
+				{Title: "Discover tests"},
+				{Title: "Command Integration", Children: []*Section{{Title: "Register in check command"}}},
+			}},
+			&Section{Title: "HTTP Connector [INTERFACE EXTENDS: Connector]", Children: []*Section{
+				{Title: "Send requests"},
+				{Title: "Command Integration", Children: []*Section{{Title: "Register in init command"}}},
+			}},
+			&Section{Title: "REST Connector [IMPLEMENTS: HTTP Connector]", Children: []*Section{
+				{Title: "Send requests"},
+				{Title: "Command Integration", Children: []*Section{{Title: "Register in init command"}}},
+			}},
+		)
+
+		errors := specification.ValidateInterfaces()
+		assert.Len(t, errors, 1)
+		assert.Equal(t, "REST Connector [IMPLEMENTS: HTTP Connector]", errors[0].Section.Title)
+		assert.Equal(t, []string{"discover tests", "command integration > register in check command"}, errors[0].Missing)
+	})
+
+	t.Run("unknown base interface is reported on the interface", func(t *testing.T) {
+		specification := build(
+			&Section{Title: "HTTP Connector [INTERFACE EXTENDS: Conector]", Children: []*Section{{Title: "Send requests"}}},
+		)
+
+		errors := specification.ValidateInterfaces()
+		assert.Len(t, errors, 1)
+		assert.Equal(t, "HTTP Connector [INTERFACE EXTENDS: Conector]: extends unknown interface 'Conector'", errors[0].Error())
+	})
+
+	t.Run("inheritance cycles are reported", func(t *testing.T) {
+		specification := build(
+			&Section{Title: "A [INTERFACE EXTENDS: B]", Children: []*Section{{Title: "One"}}},
+			&Section{Title: "B [INTERFACE EXTENDS: A]", Children: []*Section{{Title: "Two"}}},
+			&Section{Title: "C [INTERFACE EXTENDS: A]", Children: []*Section{{Title: "Three"}}},
+		)
+
+		errors := specification.ValidateInterfaces()
+		assert.Len(t, errors, 2, "only interfaces inside the cycle report it")
+		assert.Equal(t, "interface inheritance cycle: A -> B -> A", errors[0].Problem)
+		assert.Equal(t, "interface inheritance cycle: B -> A -> B", errors[1].Problem)
+	})
+}
+
+func TestValidateMultipleInterfaces(t *testing.T) {
+	specification := &Specification{Sections: []*Section{
+		{Title: "Connector [INTERFACE]", Children: []*Section{{Title: "Discover tests"}}},
+		{Title: "Configurable [INTERFACE]", Children: []*Section{{Title: "Generate default configuration"}}},
+		{Title: "Go Connector [IMPLEMENTS: Connector, Configurable, Cacheable]", Children: []*Section{
+			{Title: "Discover tests"},
+		}},
+	}}
+
+	errors := specification.ValidateInterfaces()
+	assert.Len(t, errors, 2, "errors are reported per interface")
+
+	assert.Equal(t, "Configurable", errors[0].Interface)
+	assert.Equal(t, []string{"generate default configuration"}, errors[0].Missing)
+	assert.Equal(t, "Go Connector [IMPLEMENTS: Connector, Configurable, Cacheable] is missing from Configurable: generate default configuration", errors[0].Error())
+
+	assert.Equal(t, "Cacheable", errors[1].Interface)
+	assert.Equal(t, "interface 'Cacheable' not found", errors[1].Problem)
+}
+
+func TestQualifiedInterfaceNames(t *testing.T) {
+	connector := func(dir string) *Section {
+		return &Section{
+			Title:    "Connector [INTERFACE]",
+			FilePath: filepath.Join("spec", dir, "connector.md"),
+			Line:     1,
+			Children: []*Section{{Title: "Discover " + dir}},
+		}
+	}
+	implementation := func(title string) *Section {
+		return &Section{
+			Title:    title,
+			FilePath: filepath.Join("spec", "go.md"),
+			Children: []*Section{{Title: "Discover integrations"}},
+		}
+	}
+
+	t.Run("qualified names select an interface by directory", func(t *testing.T) {
+		specification := &Specification{RootDir: "spec", Sections: []*Section{
+			connector("integrations"),
+			connector("storage"),
+			implementation("Go [IMPLEMENTS: integrations/Connector]"),
+		}}
+		assert.Empty(t, specification.ValidateInterfaces())
+	})
+
+	t.Run("ambiguous bare names are errors", func(t *testing.T) {
+		specification := &Specification{RootDir: "spec", Sections: []*Section{
+			connector("integrations"),
+			connector("storage"),
+			implementation("Go [IMPLEMENTS: Connector]"),
+		}}
+		errors := specification.ValidateInterfaces()
+		assert.Len(t, errors, 1)
+		assert.Equal(t, "interface name 'Connector' is ambiguous, qualify it as one of: integrations/Connector, storage/Connector", errors[0].Problem)
+	})
+
+	t.Run("unique bare names still work", func(t *testing.T) {
+		specification := &Specification{RootDir: "spec", Sections: []*Section{
+			connector("integrations"),
+			implementation("Go [IMPLEMENTS: Connector]"),
+		}}
+		assert.Empty(t, specification.ValidateInterfaces())
+	})
+
+	t.Run("the same qualified path cannot be defined twice", func(t *testing.T) {
+		duplicate := connector("integrations")
+		duplicate.Line = 20
+		specification := &Specification{RootDir: "spec", Sections: []*Section{
+			connector("integrations"),
+			duplicate,
+		}}
+		errors := specification.ValidateInterfaces()
+		assert.Len(t, errors, 1)
+		assert.Same(t, duplicate, errors[0].Section)
+		assert.Contains(t, errors[0].Problem, "interface 'integrations/Connector' is already defined by Connector [INTERFACE] at ")
+	})
+
+	t.Run("paths are relative to the directory of a single spec file", func(t *testing.T) {
+		root := filepath.Join("spec", "spec.md")
+		shared := &Section{
+			Title:    "Connector [INTERFACE]",
+			FilePath: filepath.Join("spec", "shared", "conn.md"),
+			Line:     1,
+			Children: []*Section{{Title: "Discover tests"}},
+		}
+		specification := &Specification{FilePath: root, RootDir: "spec", Sections: []*Section{
+			{Title: "Service", FilePath: root, Line: 1, Children: []*Section{shared}},
+			{Title: "Go [IMPLEMENTS: shared/Connector]", FilePath: root, Children: []*Section{{Title: "Discover tests"}}},
+		}}
+		assert.Empty(t, specification.ValidateInterfaces())
+	})
+}
+
+func TestApplyTestPatterns(t *testing.T) {
+	var link func(section *Section) *Section
+	link = func(section *Section) *Section {
+		for _, child := range section.Children {
+			child.Parent = section
+			link(child)
+		}
+		return section
+	}
+
+	specification := &Specification{Sections: []*Section{
+		link(&Section{Title: "Connector [INTERFACE]", TestPattern: "{impl}_test.Test{Section}", Children: []*Section{
+			{Title: "Discover tests"},
+			{Title: "Command Integration", TestPattern: "cmd.Test{Impl}{Section}", Children: []*Section{
+				{Title: "Register in init command"},
+			}},
+			{Title: "Handle empty suite"},
+		}}),
+		link(&Section{Title: "HTTP Connector [INTERFACE EXTENDS: Connector]", Children: []*Section{
+			{Title: "Send requests"},
+		}}),
+		link(&Section{Title: "Go Connector [IMPLEMENTS: Connector]", Children: []*Section{
+			{Title: "Discover tests"},
+			{Title: "Command Integration", Children: []*Section{
+				{Title: "Register in init command"},
+			}},
+			{Title: "Handle empty suite", TestName: "custom.TestEmpty", TestNames: []string{"custom.TestEmpty"}},
+		}}),
+		link(&Section{Title: "REST Connector [IMPLEMENTS: HTTP Connector]", Children: []*Section{
+			{Title: "Send requests"},
+			{Title: "Handle empty suite [N/A]", Reason: "REST has no suites"},
+		}}),
+	}}
+
+	specification.ApplyTestPatterns()
+
+	goConnector := specification.Sections[2]
+	assert.Equal(t, []string{"go_connector_test.TestDiscoverTests"}, goConnector.Children[0].Tests())
+	assert.True(t, goConnector.Children[0].TestDerived)
+	assert.Equal(t, []string{"cmd.TestGoConnectorRegisterInInitCommand"}, goConnector.Children[1].Children[0].Tests(), "the nearest pattern wins")
+	assert.Equal(t, []string{"custom.TestEmpty"}, goConnector.Children[2].Tests(), "explicit references are kept")
+	assert.False(t, goConnector.Children[2].TestDerived)
+
+	rest := specification.Sections[3]
+	assert.Equal(t, []string{"rest_connector_test.TestSendRequests"}, rest.Children[0].Tests(), "patterns are inherited by extending interfaces")
+	assert.False(t, rest.Children[1].HasTest(), "sections that need no test are left alone")
+}
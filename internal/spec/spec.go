@@ -1,22 +1,110 @@
 package spec
 
-import "strings"
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode"
+)
 
 // Specification represents a parsed specification document
 type Specification struct {
 	FilePath string
+	RootDir  string    // Directory interface paths are relative to (empty if not read from files)
+	Metadata *Metadata // Front matter of the document (nil if it has none)
 	Sections []*Section
 }
 
+// Metadata holds per-file information from a YAML front-matter block
+type Metadata struct {
+	Owner  string   `yaml:"owner,omitempty"`
+	Status string   `yaml:"status,omitempty"`
+	Tags   []string `yaml:"tags,omitempty"`
+	Title  string   `yaml:"title,omitempty"` // Display title for the document's first heading
+	Order  *int     `yaml:"order,omitempty"` // Position among its siblings when loading a directory
+}
+
+// TestMatch controls how a section with several test references is evaluated
+type TestMatch string
+
+const (
+	MatchAll TestMatch = "all" // Every referenced test must exist (default)
+	MatchAny TestMatch = "any" // At least one referenced test must exist
+)
+
+// Lifecycle describes how far a section is from being an enforced requirement
+type Lifecycle string
+
+const (
+	LifecycleActive        Lifecycle = ""           // Normal requirement, needs a test
+	LifecycleDraft         Lifecycle = "DRAFT"      // Work in progress, reported but not enforced
+	LifecycleDeprecated    Lifecycle = "DEPRECATED" // Being removed, should no longer reference tests
+	LifecycleManual        Lifecycle = "MANUAL"     // Verified by hand, needs a justification instead of a test
+	LifecycleWontFix       Lifecycle = "WONTFIX"    // Deliberately not implemented
+	LifecycleNotApplicable Lifecycle = "N/A"        // Waived interface section, needs a reason instead of a test
+)
+
+// lifecycleMarkers lists the heading markers in the order they are checked
+var lifecycleMarkers = []Lifecycle{LifecycleDraft, LifecycleDeprecated, LifecycleManual, LifecycleWontFix, LifecycleNotApplicable}
+
 // Section represents a section in the specification
 type Section struct {
-	Level    int        // Heading level (1, 2, 3...)
-	Number   string     // Auto-generated: "1.1.1"
-	Title    string     // "Parse Markdown headings"
-	Content  string     // Everything between this heading and next
-	TestName string     // "TestParseMarkdownHeadings" (empty if not a leaf)
-	Children []*Section // Nested sections
-	Parent   *Section   // Parent section (nil for root)
+	Level         int        // Heading level (1, 2, 3...)
+	Number        string     // Auto-generated: "1.1.1"
+	Title         string     // "Parse Markdown headings"
+	ID            string     // Stable requirement ID from a {#PARSER-012} heading marker
+	Content       string     // Everything between this heading and next
+	TestName      string     // "TestParseMarkdownHeadings" (empty if not a leaf)
+	TestNames     []string   // All test references, in order (TestName is the first)
+	TestMatch     TestMatch  // How TestNames are evaluated (empty means MatchAll)
+	MinMatches    int        // Tests each glob or re: reference must match (0 means 1)
+	Justification string     // Why a [MANUAL] section is verified without a test
+	Reason        string     // Why an [N/A] section does not apply
+	TestPattern   string     // Interface **Test pattern:** used to derive implementation tests
+	TestPrefix    string     // **Test prefix:** completing the test references of this section and its descendants
+	TestDerived   bool       // TestNames were derived from an interface test pattern
+	TestExpanded  bool       // TestNames were expanded from a templated reference for one example
+	HasItems      bool       // Children include task items, requirement table rows or examples
+	Tags          []string   // Tags from a **Tags:** line (see AllTags for inherited tags)
+	Metadata      *Metadata  // Front matter of the file the section came from (shared, may be nil)
+	FilePath      string     // File the section was parsed from (empty if not read from a file)
+	Line          int        // 1-based line of the heading (0 if unknown)
+	TestLine      int        // 1-based line of the first test reference (0 if none)
+	TestLines     []int      // 1-based line of each of TestNames (0 or missing if unknown)
+	Children      []*Section // Nested sections
+	Parent        *Section   // Parent section (nil for root)
+}
+
+// Location returns "file:line" for the section heading, or just the file
+// or an empty string when that is all that is known
+func (s *Section) Location() string {
+	return formatLocation(s.FilePath, s.Line)
+}
+
+// TestLocation returns "file:line" for the section's test reference,
+// falling back to the heading location when there is none
+func (s *Section) TestLocation() string {
+	if s.TestLine == 0 {
+		return s.Location()
+	}
+	return formatLocation(s.FilePath, s.TestLine)
+}
+
+// TestReferenceLocation returns "file:line" for the i-th of the section's test
+// references, falling back to TestLocation when its line is unknown
+func (s *Section) TestReferenceLocation(i int) string {
+	if i < len(s.TestLines) && s.TestLines[i] != 0 {
+		return formatLocation(s.FilePath, s.TestLines[i])
+	}
+	return s.TestLocation()
+}
+
+func formatLocation(path string, line int) string {
+	if path == "" || line == 0 {
+		return path
+	}
+	return fmt.Sprintf("%s:%d", path, line)
 }
 
 // IsLeaf returns true if this section has no children
@@ -26,7 +114,24 @@ func (s *Section) IsLeaf() bool {
 
 // HasTest returns true if this section has a test reference
 func (s *Section) HasTest() bool {
-	return s.TestName != ""
+	return len(s.Tests()) > 0
+}
+
+// Tests returns all test references for this section.
+// Sections built with only TestName set are treated as having a single reference.
+func (s *Section) Tests() []string {
+	if len(s.TestNames) > 0 {
+		return s.TestNames
+	}
+	if s.TestName != "" {
+		return []string{s.TestName}
+	}
+	return nil
+}
+
+// MatchesAny returns true if any single referenced test is enough to cover this section
+func (s *Section) MatchesAny() bool {
+	return s.TestMatch == MatchAny
 }
 
 // AllLeaves returns all leaf sections in the tree
@@ -51,25 +156,160 @@ func (s *Specification) AllLeaves() []*Section {
 func (s *Specification) RequiredTests() []string {
 	var tests []string
 	for _, leaf := range s.AllLeaves() {
-		if leaf.HasTest() {
-			tests = append(tests, leaf.TestName)
-		}
+		tests = append(tests, leaf.Tests()...)
 	}
 	return tests
 }
 
 func (s *Section) IsInterface() bool {
-	return strings.Contains(s.Title, "[INTERFACE]")
+	return interfaceMarkerPattern.MatchString(s.Title)
+}
+
+// IsOptional returns true if an interface section is marked [OPTIONAL], so
+// implementations may leave it out
+func (s *Section) IsOptional() bool {
+	return strings.Contains(s.Title, "[OPTIONAL]")
+}
+
+// IsNotApplicable returns true if the section itself carries an [N/A] marker
+func (s *Section) IsNotApplicable() bool {
+	return strings.Contains(s.Title, "["+string(LifecycleNotApplicable)+"]")
+}
+
+// interfaceMarkerPattern matches [INTERFACE] and [INTERFACE EXTENDS: Base]
+var interfaceMarkerPattern = regexp.MustCompile(`\[INTERFACE(?:\s+EXTENDS:\s*([^\]]*))?\]`)
+
+// InterfaceName returns the name implementations use to refer to this interface:
+// the title without its [INTERFACE] marker. Empty if the section is not an interface.
+func (s *Section) InterfaceName() string {
+	loc := interfaceMarkerPattern.FindStringIndex(s.Title)
+	if loc == nil {
+		return ""
+	}
+	return strings.TrimSpace(s.Title[:loc[0]])
+}
+
+// GetExtendedInterface returns the name of the base interface from an
+// [INTERFACE EXTENDS: Base] marker, or an empty string
+func (s *Section) GetExtendedInterface() string {
+	matches := interfaceMarkerPattern.FindStringSubmatch(s.Title)
+	if matches == nil {
+		return ""
+	}
+	return strings.TrimSpace(matches[1])
+}
+
+// Lifecycle returns the lifecycle of this section. Markers such as [DRAFT] apply to
+// the section and all of its descendants; the nearest marker wins. Sections without
+// a marker fall back to a matching status in their file's front matter.
+func (s *Section) Lifecycle() Lifecycle {
+	for current := s; current != nil; current = current.Parent {
+		for _, lifecycle := range lifecycleMarkers {
+			if strings.Contains(current.Title, "["+string(lifecycle)+"]") {
+				return lifecycle
+			}
+		}
+	}
+
+	if s.Metadata != nil {
+		for _, lifecycle := range lifecycleMarkers {
+			if strings.EqualFold(s.Metadata.Status, string(lifecycle)) {
+				return lifecycle
+			}
+		}
+	}
+
+	return LifecycleActive
+}
+
+// NotApplicableReason returns the **Reason:** given for an [N/A] section. Sections
+// below an [N/A] section share the reason of the marked section.
+func (s *Section) NotApplicableReason() string {
+	for current := s; current != nil; current = current.Parent {
+		if current.Reason != "" {
+			return current.Reason
+		}
+		if strings.Contains(current.Title, "["+string(LifecycleNotApplicable)+"]") {
+			break
+		}
+	}
+	return ""
+}
+
+// AllTags returns the tags that apply to this section: its own **Tags:** line and
+// [TAGS: ...] heading marker, those of all its ancestors, and the tags from its
+// file's front matter. Tags are lowercased and returned once each.
+func (s *Section) AllTags() []string {
+	var tags []string
+	seen := make(map[string]bool)
+	add := func(values []string) {
+		for _, tag := range values {
+			tag = strings.ToLower(strings.TrimSpace(tag))
+			if tag != "" && !seen[tag] {
+				seen[tag] = true
+				tags = append(tags, tag)
+			}
+		}
+	}
+
+	for current := s; current != nil; current = current.Parent {
+		add(current.Tags)
+		add(headingTags(current.Title))
+		if current.Metadata != nil {
+			add(current.Metadata.Tags)
+		}
+	}
+	return tags
+}
+
+// HasTag returns true if the tag applies to this section (case-insensitive)
+func (s *Section) HasTag(tag string) bool {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	for _, t := range s.AllTags() {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// headingTags extracts tags from [TAGS: a, b] or [TAG: a] markers in a heading
+func headingTags(title string) []string {
+	var tags []string
+	for _, marker := range []string{"[TAGS:", "[TAG:"} {
+		rest := title
+		for {
+			idx := strings.Index(rest, marker)
+			if idx == -1 {
+				break
+			}
+			rest = rest[idx+len(marker):]
+			end := strings.Index(rest, "]")
+			if end == -1 {
+				break
+			}
+			for _, tag := range strings.Split(rest[:end], ",") {
+				tags = append(tags, strings.TrimSpace(tag))
+			}
+			rest = rest[end:]
+		}
+	}
+	return tags
 }
 
 // RequiresTest returns true if this section requires a test reference
-// Interfaces and their children don't require tests
+// Interfaces and their children don't require tests, and neither do
+// draft, deprecated, manual or won't-fix sections
 func (s *Section) RequiresTest() bool {
 	// Only leaf sections normally require tests
 	if !s.IsLeaf() {
 		return false
 	}
 	
+	if s.Lifecycle() != LifecycleActive {
+		return false
+	}
+	
 	// Check if this section or any ancestor is an interface
 	current := s
 	for current != nil {
@@ -82,75 +322,307 @@ func (s *Section) RequiresTest() bool {
 	return true
 }
 
+// GetImplementedInterface returns the first interface named in an [IMPLEMENTS: X] marker,
+// or an empty string if the section implements no interface
 func (s *Section) GetImplementedInterface() string {
-	// Look for [IMPLEMENTS: InterfaceName] pattern
-	if idx := strings.Index(s.Title, "[IMPLEMENTS:"); idx != -1 {
-		// Find the closing bracket
-		endIdx := strings.Index(s.Title[idx:], "]")
-		if endIdx != -1 {
-			// Extract the interface name between : and ]
-			startIdx := idx + len("[IMPLEMENTS:")
-			interfaceName := s.Title[startIdx : idx+endIdx]
-			// Trim any whitespace
-			return strings.TrimSpace(interfaceName)
-		}
+	if interfaces := s.GetImplementedInterfaces(); len(interfaces) > 0 {
+		return interfaces[0]
 	}
 	return ""
 }
 
-// ValidateImplementation checks if an implementation has all required sections from the interface
-// Returns a list of missing section titles (normalized to lowercase)
+// implementsMarkerPattern matches an [IMPLEMENTS: A, B] marker
+var implementsMarkerPattern = regexp.MustCompile(`\[IMPLEMENTS:([^\]]*)\]`)
+
+// GetImplementedInterfaces returns every interface the section implements, from a
+// comma-separated [IMPLEMENTS: A, B] marker or several markers, without duplicates
+func (s *Section) GetImplementedInterfaces() []string {
+	var interfaces []string
+	seen := make(map[string]bool)
+	for _, matches := range implementsMarkerPattern.FindAllStringSubmatch(s.Title, -1) {
+		for _, name := range strings.Split(matches[1], ",") {
+			name = strings.TrimSpace(name)
+			if name != "" && !seen[name] {
+				seen[name] = true
+				interfaces = append(interfaces, name)
+			}
+		}
+	}
+	return interfaces
+}
+
+// ValidateImplementation checks if an implementation has all required sections from the interface,
+// including nested sub-sections at every level.
+// Returns a list of missing section paths (normalized to lowercase), such as
+// "command integration > register in init command" for a missing grandchild.
+// Descendants of a missing section are not reported separately.
 func ValidateImplementation(impl *Section, iface *Section) []string {
+	return missingSections(impl, iface, "")
+}
+
+// missingSections compares the children of an implementation section with the
+// children of the matching interface section and recurses into matched pairs
+func missingSections(impl *Section, iface *Section, path string) []string {
 	var missing []string
 	
-	// Get all child titles from interface (normalized to lowercase)
-	requiredSections := make(map[string]bool)
+	// Get all child sections from implementation (normalized to lowercase)
+	implementedSections := make(map[string]*Section)
+	for _, child := range impl.Children {
+		implementedSections[normalizeTitle(child.Title)] = child
+	}
+	
+	// Walk the interface in document order so the result is stable
+	for _, required := range iface.Children {
+		name := normalizeTitle(required.Title)
+		if path != "" {
+			name = path + " > " + name
+		}
+		
+		implemented, found := implementedSections[normalizeTitle(required.Title)]
+		if !found {
+			// Optional sections may be left out, together with everything below them
+			if !required.IsOptional() {
+				missing = append(missing, name)
+			}
+			continue
+		}
+		if implemented.IsNotApplicable() {
+			// An [N/A] section waives the interface section and everything below it
+			continue
+		}
+		missing = append(missing, missingSections(implemented, required, name)...)
+	}
+	
+	return missing
+}
+
+// unexpectedSections finds implementation children that the interface section
+// does not declare. Sections below an interface leaf are free-form and not checked.
+func unexpectedSections(impl *Section, iface *Section, path string) []UnexpectedSection {
+	if len(iface.Children) == 0 {
+		return nil
+	}
+	
+	declared := make(map[string]*Section)
 	for _, child := range iface.Children {
-		normalized := strings.ToLower(strings.TrimSpace(child.Title))
-		requiredSections[normalized] = true
+		declared[normalizeTitle(child.Title)] = child
 	}
 	
-	// Get all child titles from implementation (normalized to lowercase)
-	implementedSections := make(map[string]bool)
+	var unexpected []UnexpectedSection
 	for _, child := range impl.Children {
-		normalized := strings.ToLower(strings.TrimSpace(child.Title))
-		implementedSections[normalized] = true
+		name := normalizeTitle(child.Title)
+		if path != "" {
+			name = path + " > " + name
+		}
+		
+		match, found := declared[normalizeTitle(child.Title)]
+		if found && child.IsNotApplicable() {
+			continue
+		}
+		if !found {
+			unexpected = append(unexpected, UnexpectedSection{
+				Path:       name,
+				Suggestion: closestTitle(child.Title, iface.Children),
+			})
+			continue
+		}
+		unexpected = append(unexpected, unexpectedSections(child, match, name)...)
+	}
+	return unexpected
+}
+
+// closestTitle returns the normalized title of the candidate closest to title by
+// edit distance, or an empty string if none is close enough to be a likely typo
+func closestTitle(title string, candidates []*Section) string {
+	title = normalizeTitle(title)
+	best := ""
+	bestDistance := -1
+	for _, candidate := range candidates {
+		name := normalizeTitle(candidate.Title)
+		distance := levenshtein(title, name)
+		if bestDistance == -1 || distance < bestDistance {
+			best, bestDistance = name, distance
+		}
 	}
 	
-	// Find missing sections
-	for required := range requiredSections {
-		if !implementedSections[required] {
-			missing = append(missing, required)
+	// Allow roughly one edit per three characters
+	if bestDistance == -1 || bestDistance > max(2, len(title)/3) {
+		return ""
+	}
+	return best
+}
+
+// levenshtein returns the number of single-character edits needed to turn a into b
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
 		}
+		previous, current = current, previous
 	}
+	return previous[len(rb)]
+}
+
+// normalizeTitle returns the form of a section title used to match
+// implementation sections with interface sections
+func normalizeTitle(title string) string {
+	title = headingMarkerPattern.ReplaceAllString(title, " ")
+	return strings.ToLower(strings.Join(strings.Fields(title), " "))
+}
+
+// headingMarkerPattern matches upper-case heading markers such as [OPTIONAL],
+// [DRAFT] or [TAGS: api], which are not part of a section's name
+var headingMarkerPattern = regexp.MustCompile(`\[[A-Z][A-Z/_ ]*(?::[^\]]*)?\]`)
+
+// InterfaceError describes an implementation that does not conform to its
+// interface, or an interface whose inheritance cannot be resolved
+type InterfaceError struct {
+	Section    *Section            // The implementation, or the interface for inheritance errors
+	Interface  string              // Name of the interface involved
+	Missing    []string            // Missing section paths, normalized to lowercase
+	Unexpected []UnexpectedSection // Sections no implemented interface declares (strict mode only)
+	Problem    string              // Set instead of Missing for errors such as an unknown interface
+}
+
+// UnexpectedSection is an implementation section that does not exist in its interfaces
+type UnexpectedSection struct {
+	Path       string // Section path, normalized to lowercase
+	Suggestion string // Closest interface section title at the same level, if any is close
+}
+
+func (u UnexpectedSection) String() string {
+	if u.Suggestion == "" {
+		return u.Path
+	}
+	return fmt.Sprintf("%s (did you mean '%s'?)", u.Path, u.Suggestion)
+}
+
+func (e InterfaceError) Error() string {
+	if e.Problem != "" {
+		return fmt.Sprintf("%s: %s", e.Section.Title, e.Problem)
+	}
+	if len(e.Unexpected) > 0 {
+		var unexpected []string
+		for _, section := range e.Unexpected {
+			unexpected = append(unexpected, section.String())
+		}
+		return fmt.Sprintf("%s has unexpected sections: %s", e.Section.Title, strings.Join(unexpected, ", "))
+	}
+	// Name the interface when the section implements several
+	if len(e.Section.GetImplementedInterfaces()) > 1 {
+		return fmt.Sprintf("%s is missing from %s: %s", e.Section.Title, e.Interface, strings.Join(e.Missing, ", "))
+	}
+	return fmt.Sprintf("%s is missing: %s", e.Section.Title, strings.Join(e.Missing, ", "))
+}
+
+// ValidateInterfaces checks all implementations against their interfaces, with
+// inherited sections of extended interfaces resolved first.
+// Returns the errors in document order.
+func (s *Specification) ValidateInterfaces() []InterfaceError {
+	return s.validateInterfaces(false)
+}
+
+// ValidateInterfacesStrict works like ValidateInterfaces, and also reports
+// implementation sections that none of the implemented interfaces declare
+func (s *Specification) ValidateInterfacesStrict() []InterfaceError {
+	return s.validateInterfaces(true)
+}
+
+func (s *Specification) validateInterfaces(strict bool) []InterfaceError {
+	set := s.collectInterfaces()
+	errors := set.errors
 	
-	return missing
+	// Validate each implementation against every interface it implements
+	for _, impl := range set.implementations {
+		names := impl.GetImplementedInterfaces()
+		var declared []*Section // Sections of all implemented interfaces together
+		allFound := true
+		for _, interfaceName := range names {
+			iface, problem := set.lookup(interfaceName)
+			if iface == nil {
+				errors = append(errors, InterfaceError{Section: impl, Interface: interfaceName, Problem: problem})
+				allFound = false
+				continue
+			}
+			declared = mergeSections(declared, iface.Children)
+			
+			// Check for missing sections
+			missing := ValidateImplementation(impl, iface)
+			if len(missing) > 0 {
+				errors = append(errors, InterfaceError{Section: impl, Interface: interfaceName, Missing: missing})
+			}
+		}
+		
+		// Extra sections can only be judged against the complete set of interfaces
+		if strict && allFound {
+			unexpected := unexpectedSections(impl, &Section{Children: declared}, "")
+			if len(unexpected) > 0 {
+				errors = append(errors, InterfaceError{Section: impl, Interface: strings.Join(names, ", "), Unexpected: unexpected})
+			}
+		}
+	}
+	
+	return errors
 }
 
-// ValidateInterfaces checks all implementations against their interfaces
-// Returns a map of implementation titles to their missing sections
-func (s *Specification) ValidateInterfaces() map[string][]string {
-	errors := make(map[string][]string)
+// interfaceSet holds the interfaces and implementations of a specification,
+// with the inheritance of every interface resolved
+type interfaceSet struct {
+	index           *interfaceIndex
+	implementations []*Section            // In document order
+	resolved        map[*Section]*Section // Interface to its sections including inherited ones
+	errors          []InterfaceError      // Problems with the interface definitions themselves
+}
+
+// collectInterfaces finds all interfaces and implementations and resolves inheritance
+func (s *Specification) collectInterfaces() *interfaceSet {
+	set := &interfaceSet{
+		index:    newInterfaceIndex(s.RootDir),
+		resolved: make(map[*Section]*Section),
+	}
+	var interfaceOrder []*Section
 	
-	// Collect all interfaces and implementations
-	interfaces := make(map[string]*Section)
-	implementations := make(map[*Section]string)
+	// A file included in several places yields copies of the same heading,
+	// which declare one interface or implementation rather than several
+	seen := make(map[string]bool)
 	
 	var walk func(*Section)
 	walk = func(section *Section) {
+		if section.FilePath != "" && section.Line != 0 && (section.IsInterface() || len(section.GetImplementedInterfaces()) > 0) {
+			if seen[section.Location()] {
+				return
+			}
+			seen[section.Location()] = true
+		}
+		
 		// Check if it's an interface
 		if section.IsInterface() {
-			// Extract clean name without [INTERFACE] marker
-			name := strings.TrimSpace(section.Title)
-			if idx := strings.Index(name, "[INTERFACE]"); idx != -1 {
-				name = strings.TrimSpace(name[:idx])
+			if existing := set.index.add(section); existing != nil {
+				path := set.index.paths[section]
+				set.errors = append(set.errors, InterfaceError{
+					Section:   section,
+					Interface: path,
+					Problem:   "interface '" + path + "' is already defined by " + describe(existing),
+				})
 			}
-			interfaces[name] = section
+			interfaceOrder = append(interfaceOrder, section)
 		}
 		
 		// Check if it's an implementation
-		if implInterface := section.GetImplementedInterface(); implInterface != "" {
-			implementations[section] = implInterface
+		if len(section.GetImplementedInterfaces()) > 0 {
+			set.implementations = append(set.implementations, section)
 		}
 		
 		// Recurse through children
@@ -164,20 +636,418 @@ func (s *Specification) ValidateInterfaces() map[string][]string {
 		walk(root)
 	}
 	
-	// Validate each implementation
-	for impl, interfaceName := range implementations {
-		iface, found := interfaces[interfaceName]
-		if !found {
-			errors[impl.Title] = []string{"interface '" + interfaceName + "' not found"}
-			continue
+	// Resolve inheritance, reporting unknown bases and cycles on the interface itself
+	for _, iface := range interfaceOrder {
+		merged, problem := resolveInterface(iface, set.index)
+		set.resolved[iface] = merged
+		if problem != "" {
+			set.errors = append(set.errors, InterfaceError{Section: iface, Interface: set.index.paths[iface], Problem: problem})
+		}
+	}
+	
+	return set
+}
+
+// lookup returns the resolved interface an implementation refers to, or nil and
+// the reason it cannot be used
+func (set *interfaceSet) lookup(name string) (*Section, string) {
+	iface, candidates := set.index.find(name)
+	if iface == nil {
+		if len(candidates) > 0 {
+			return nil, ambiguousName(name, candidates)
+		}
+		return nil, "interface '" + name + "' not found"
+	}
+	return set.resolved[iface], ""
+}
+
+// InheritedTestPrefix returns the **Test prefix:** of the section or of its
+// nearest ancestor that declares one, or an empty string
+func (s *Section) InheritedTestPrefix() string {
+	for current := s; current != nil; current = current.Parent {
+		if current.TestPrefix != "" {
+			return current.TestPrefix
+		}
+	}
+	return ""
+}
+
+// connectorQualifierPattern splits the connector type off a qualified test
+// reference such as go:pkg.TestLogin
+var connectorQualifierPattern = regexp.MustCompile(`^([a-z]+:)([^:].*)$`)
+
+// absoluteTestMarker starts a test reference that is used as written, without
+// the test prefix in scope
+const absoluteTestMarker = "/"
+
+// PrefixTestReference completes a test reference with a test prefix. A reference
+// starting with / is absolute and only loses the /, and re: regular expressions
+// are left alone. The prefix goes after the connector type of a qualified reference.
+func PrefixTestReference(prefix string, reference string) string {
+	if strings.HasPrefix(reference, "re:") {
+		return reference
+	}
+	qualifier := ""
+	if matches := connectorQualifierPattern.FindStringSubmatch(reference); matches != nil {
+		qualifier, reference = matches[1], matches[2]
+	}
+
+	if absolute, ok := strings.CutPrefix(reference, absoluteTestMarker); ok {
+		return qualifier + absolute
+	}
+	return qualifier + prefix + reference
+}
+
+// ApplyTestPrefixes completes the test references of every section with its
+// inherited **Test prefix:**, so specs can name tests relative to a package.
+// It must run once, as completed references are not recognized again.
+func (s *Specification) ApplyTestPrefixes() {
+	var walk func(*Section)
+	walk = func(section *Section) {
+		prefix := section.InheritedTestPrefix()
+		for i, name := range section.TestNames {
+			section.TestNames[i] = PrefixTestReference(prefix, name)
+		}
+		if section.TestName != "" {
+			section.TestName = PrefixTestReference(prefix, section.TestName)
+		}
+		for _, child := range section.Children {
+			walk(child)
+		}
+	}
+	for _, root := range s.Sections {
+		walk(root)
+	}
+}
+
+// ApplyTestPatterns derives test references for implementation sections without
+// any, from the **Test pattern:** of the interface section they implement or its
+// nearest ancestor. Patterns may use these placeholders:
+//
+//	{impl}, {Impl}       the implementation name in snake_case or PascalCase
+//	{section}, {Section} the interface section title in snake_case or PascalCase
+//
+// Sections that do not require a test, or already reference one, are left alone.
+func (s *Specification) ApplyTestPatterns() {
+	set := s.collectInterfaces()
+	for _, impl := range set.implementations {
+		name := headingMarkerPattern.ReplaceAllString(impl.Title, " ")
+		for _, interfaceName := range impl.GetImplementedInterfaces() {
+			if iface, _ := set.lookup(interfaceName); iface != nil {
+				deriveTests(impl, iface, iface.TestPattern, name)
+			}
+		}
+	}
+}
+
+// deriveTests walks an implementation alongside the matching interface sections,
+// filling in tests for leaves from the pattern in scope
+func deriveTests(impl *Section, iface *Section, pattern string, implName string) {
+	if iface.TestPattern != "" {
+		pattern = iface.TestPattern
+	}
+	
+	if impl.IsLeaf() {
+		if pattern != "" && !impl.HasTest() && impl.RequiresTest() {
+			sectionName := headingMarkerPattern.ReplaceAllString(iface.Title, " ")
+			testName := strings.NewReplacer(
+				"{impl}", snakeCase(implName),
+				"{Impl}", pascalCase(implName),
+				"{section}", snakeCase(sectionName),
+				"{Section}", pascalCase(sectionName),
+			).Replace(pattern)
+			impl.TestName = testName
+			impl.TestNames = []string{testName}
+			impl.TestDerived = true
+		}
+		return
+	}
+	
+	declared := make(map[string]*Section)
+	for _, child := range iface.Children {
+		declared[normalizeTitle(child.Title)] = child
+	}
+	for _, child := range impl.Children {
+		if match, found := declared[normalizeTitle(child.Title)]; found {
+			deriveTests(child, match, pattern, implName)
+		}
+	}
+}
+
+// nameWords splits a title into its alphanumeric words
+func nameWords(title string) []string {
+	return strings.FieldsFunc(title, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// snakeCase turns "Go Connector" into "go_connector"
+func snakeCase(title string) string {
+	return strings.ToLower(strings.Join(nameWords(title), "_"))
+}
+
+// pascalCase turns "discover tests in project" into "DiscoverTestsInProject"
+func pascalCase(title string) string {
+	var b strings.Builder
+	for _, word := range nameWords(title) {
+		runes := []rune(word)
+		b.WriteRune(unicode.ToUpper(runes[0]))
+		b.WriteString(string(runes[1:]))
+	}
+	return b.String()
+}
+
+// resolveInterface returns an interface with the sections of all its base
+// interfaces merged in. When the chain of bases is broken, the sections that
+// could be resolved are returned together with a description of the problem.
+func resolveInterface(iface *Section, interfaces *interfaceIndex) (*Section, string) {
+	chain := []*Section{iface}
+	problem := ""
+	for current := iface; current.GetExtendedInterface() != ""; {
+		baseName := current.GetExtendedInterface()
+		base, candidates := interfaces.find(baseName)
+		if base == nil {
+			// Only the interface naming the unknown base reports it
+			if current == iface && len(candidates) > 0 {
+				problem = "extends an " + ambiguousName(baseName, candidates)
+			} else if current == iface {
+				problem = "extends unknown interface '" + baseName + "'"
+			}
+			break
 		}
 		
-		// Check for missing sections
-		missing := ValidateImplementation(impl, iface)
-		if len(missing) > 0 {
-			errors[impl.Title] = missing
+		if base == iface {
+			names := []string{}
+			for _, section := range chain {
+				names = append(names, section.InterfaceName())
+			}
+			problem = "interface inheritance cycle: " + strings.Join(names, " -> ") + " -> " + iface.InterfaceName()
+			break
 		}
+		if containsSection(chain, base) {
+			// A cycle further up the chain is reported by the interfaces in it
+			break
+		}
+		
+		chain = append(chain, base)
+		current = base
 	}
 	
-	return errors
-}
\ No newline at end of file
+	// Apply the chain from the most basic interface down
+	var children []*Section
+	for i := len(chain) - 1; i >= 0; i-- {
+		children = mergeSections(children, chain[i].Children)
+	}
+	// The nearest interface in the chain declaring a test pattern provides it
+	pattern := ""
+	for _, section := range chain {
+		if section.TestPattern != "" {
+			pattern = section.TestPattern
+			break
+		}
+	}
+	return &Section{Title: iface.Title, TestPattern: pattern, Children: children}, problem
+}
+
+// interfaceIndex finds interfaces by their bare name or by their qualified path:
+// the directory of their file relative to the specification root, then the name,
+// such as "integrations/Connector"
+type interfaceIndex struct {
+	root   string                // Directory the specification was loaded from, or the directory of its file
+	byPath map[string]*Section   // Qualified path to interface
+	byName map[string][]*Section // Bare name to every interface using it
+	paths  map[*Section]string   // Interface to its qualified path
+}
+
+func newInterfaceIndex(root string) *interfaceIndex {
+	return &interfaceIndex{
+		root:   root,
+		byPath: make(map[string]*Section),
+		byName: make(map[string][]*Section),
+		paths:  make(map[*Section]string),
+	}
+}
+
+// add registers an interface. If another interface already uses the same
+// qualified path, that interface is returned and the new one is not addressable by path.
+func (idx *interfaceIndex) add(iface *Section) *Section {
+	name := iface.InterfaceName()
+	path := name
+	if dir := idx.directoryOf(iface); dir != "" {
+		path = dir + "/" + name
+	}
+	
+	idx.paths[iface] = path
+	idx.byName[name] = append(idx.byName[name], iface)
+	if existing, found := idx.byPath[path]; found {
+		return existing
+	}
+	idx.byPath[path] = iface
+	return nil
+}
+
+// directoryOf returns the slash-separated directory of the section's file relative
+// to the specification root, or an empty string for the root itself
+func (idx *interfaceIndex) directoryOf(section *Section) string {
+	if section.FilePath == "" || idx.root == "" {
+		return ""
+	}
+	dir, err := filepath.Rel(idx.root, filepath.Dir(section.FilePath))
+	if err != nil || dir == "." {
+		return ""
+	}
+	return filepath.ToSlash(dir)
+}
+
+// find looks up an interface by qualified path or bare name. A bare name used by
+// interfaces in several directories is ambiguous and must be qualified; in that
+// case no interface is returned, only the qualified paths to choose from.
+func (idx *interfaceIndex) find(ref string) (*Section, []string) {
+	if iface, found := idx.byPath[ref]; found {
+		return iface, nil
+	}
+	if strings.Contains(ref, "/") {
+		return nil, nil
+	}
+	
+	candidates := idx.byName[ref]
+	if len(candidates) == 1 {
+		return candidates[0], nil
+	}
+	
+	var paths []string
+	for _, candidate := range candidates {
+		paths = append(paths, idx.paths[candidate])
+	}
+	return nil, paths
+}
+
+// ambiguousName describes a bare interface name that matches several interfaces
+func ambiguousName(ref string, paths []string) string {
+	return "interface name '" + ref + "' is ambiguous, qualify it as one of: " + strings.Join(paths, ", ")
+}
+
+// describe names a section by its title and, when known, its location
+func describe(section *Section) string {
+	if location := section.Location(); location != "" {
+		return section.Title + " at " + location
+	}
+	return section.Title
+}
+
+// mergeSections combines inherited interface sections with the sections an
+// interface declares itself. Sections with the same title are merged recursively.
+// The result is a new tree; the inputs are not modified.
+func mergeSections(inherited []*Section, own []*Section) []*Section {
+	merged := make([]*Section, 0, len(inherited)+len(own))
+	index := make(map[string]int)
+	for _, section := range inherited {
+		index[normalizeTitle(section.Title)] = len(merged)
+		merged = append(merged, section)
+	}
+	
+	for _, section := range own {
+		if i, found := index[normalizeTitle(section.Title)]; found {
+			pattern := section.TestPattern
+			if pattern == "" {
+				pattern = merged[i].TestPattern
+			}
+			merged[i] = &Section{
+				Title:       section.Title,
+				TestPattern: pattern,
+				Children:    mergeSections(merged[i].Children, section.Children),
+			}
+			continue
+		}
+		index[normalizeTitle(section.Title)] = len(merged)
+		merged = append(merged, section)
+	}
+	return merged
+}
+
+func containsSection(sections []*Section, section *Section) bool {
+	for _, s := range sections {
+		if s == section {
+			return true
+		}
+	}
+	return false
+}
+
+// DuplicateIDs returns every requirement ID used by more than one section,
+// mapped to the sections that use it in document order
+func (s *Specification) DuplicateIDs() map[string][]*Section {
+	byID := make(map[string][]*Section)
+
+	// A file included in several places yields copies of the same heading,
+	// which are one requirement rather than duplicates
+	seen := make(map[string]bool)
+
+	var walk func(*Section)
+	walk = func(section *Section) {
+		known := section.FilePath != "" && section.Line != 0
+		if section.ID != "" && !(known && seen[section.Location()]) {
+			if known {
+				seen[section.Location()] = true
+			}
+			byID[section.ID] = append(byID[section.ID], section)
+		}
+		for _, child := range section.Children {
+			walk(child)
+		}
+	}
+	for _, root := range s.Sections {
+		walk(root)
+	}
+
+	duplicates := make(map[string][]*Section)
+	for id, sections := range byID {
+		if len(sections) > 1 {
+			duplicates[id] = sections
+		}
+	}
+	return duplicates
+}
+
+// Filter returns a copy of the specification containing only the leaf sections
+// accepted by keep, together with their ancestors. Parent sections left without
+// any children are dropped. The original specification is not modified.
+func (s *Specification) Filter(keep func(*Section) bool) *Specification {
+	var prune func(section *Section, parent *Section) *Section
+	prune = func(section *Section, parent *Section) *Section {
+		if section.IsLeaf() {
+			if !keep(section) {
+				return nil
+			}
+			clone := *section
+			clone.Parent = parent
+			return &clone
+		}
+
+		clone := *section
+		clone.Parent = parent
+		clone.Children = nil
+		for _, child := range section.Children {
+			if kept := prune(child, &clone); kept != nil {
+				clone.Children = append(clone.Children, kept)
+			}
+		}
+		if len(clone.Children) == 0 {
+			return nil
+		}
+		return &clone
+	}
+
+	filtered := &Specification{
+		FilePath: s.FilePath,
+		RootDir:  s.RootDir,
+		Metadata: s.Metadata,
+		Sections: []*Section{},
+	}
+	for _, root := range s.Sections {
+		if kept := prune(root, nil); kept != nil {
+			filtered.Sections = append(filtered.Sections, kept)
+		}
+	}
+	return filtered
+}
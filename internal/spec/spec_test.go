@@ -407,3 +407,259 @@ func TestSection_RequiresTest(t *testing.T) {
 		})
 	}
 }
+
+func TestSection_Tests(t *testing.T) {
+	tests := []struct {
+		name     string
+		section  *Section
+		expected []string
+	}{
+		{
+			name:     "no test references",
+			section:  &Section{},
+			expected: nil,
+		},
+		{
+			name:     "single TestName",
+			section:  &Section{TestName: "TestOne"},
+			expected: []string{"TestOne"},
+		},
+		{
+			name: "multiple TestNames",
+			section: &Section{
+				TestName:  "TestUnit",
+				TestNames: []string{"TestUnit", "TestIntegration"},
+			},
+			expected: []string{"TestUnit", "TestIntegration"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.section.Tests()
+			if len(result) != len(tt.expected) {
+				t.Fatalf("Tests() = %q, want %q", result, tt.expected)
+			}
+			for i := range result {
+				if result[i] != tt.expected[i] {
+					t.Errorf("Tests()[%d] = %q, want %q", i, result[i], tt.expected[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSpecification_DuplicateIDs(t *testing.T) {
+	first := &Section{Title: "Parse headings", ID: "PARSER-012"}
+	second := &Section{Title: "Parse setext headings", ID: "PARSER-012"}
+	unique := &Section{Title: "Extract tests", ID: "PARSER-013"}
+	noID := &Section{Title: "No ID"}
+
+	specification := &Specification{
+		Sections: []*Section{
+			{
+				Title:    "Parser",
+				Children: []*Section{first, unique, noID},
+			},
+			{
+				Title:    "Other file",
+				Children: []*Section{second},
+			},
+		},
+	}
+
+	duplicates := specification.DuplicateIDs()
+
+	if len(duplicates) != 1 {
+		t.Fatalf("DuplicateIDs() returned %d IDs, want 1", len(duplicates))
+	}
+	sections := duplicates["PARSER-012"]
+	if len(sections) != 2 || sections[0] != first || sections[1] != second {
+		t.Errorf("DuplicateIDs()[PARSER-012] = %v, want both sections in document order", sections)
+	}
+}
+
+func TestSpecification_Filter(t *testing.T) {
+	security := &Metadata{Owner: "security-team"}
+	keepLeaf := &Section{Title: "Keep", Metadata: security}
+	dropLeaf := &Section{Title: "Drop"}
+	dropOnly := &Section{Title: "Drop too"}
+	mixed := &Section{Title: "Mixed", Children: []*Section{keepLeaf, dropLeaf}}
+	empty := &Section{Title: "Nothing kept", Children: []*Section{dropOnly}}
+	keepLeaf.Parent = mixed
+	dropLeaf.Parent = mixed
+	dropOnly.Parent = empty
+
+	original := &Specification{Sections: []*Section{mixed, empty}}
+
+	filtered := original.Filter(func(s *Section) bool {
+		return s.Metadata == security
+	})
+
+	if len(filtered.Sections) != 1 {
+		t.Fatalf("filtered spec has %d root sections, want 1", len(filtered.Sections))
+	}
+	root := filtered.Sections[0]
+	if root.Title != "Mixed" || len(root.Children) != 1 || root.Children[0].Title != "Keep" {
+		t.Errorf("filtered tree = %q with %d children, want Mixed > Keep", root.Title, len(root.Children))
+	}
+	if root.Children[0].Parent != root {
+		t.Errorf("kept child should point at the filtered parent")
+	}
+
+	// The original tree must be untouched
+	if len(mixed.Children) != 2 || keepLeaf.Parent != mixed {
+		t.Errorf("Filter() modified the original specification")
+	}
+}
+
+func TestSection_Lifecycle(t *testing.T) {
+	newChild := func(parentTitle string, metadata *Metadata) *Section {
+		parent := &Section{Title: parentTitle, Metadata: metadata}
+		child := &Section{Title: "Child", Parent: parent, Metadata: metadata}
+		parent.Children = []*Section{child}
+		return child
+	}
+
+	tests := []struct {
+		name         string
+		section      *Section
+		expected     Lifecycle
+		requiresTest bool
+	}{
+		{
+			name:         "plain section is active",
+			section:      &Section{Title: "Feature"},
+			expected:     LifecycleActive,
+			requiresTest: true,
+		},
+		{
+			name:         "draft marker",
+			section:      &Section{Title: "Feature [DRAFT]"},
+			expected:     LifecycleDraft,
+			requiresTest: false,
+		},
+		{
+			name:         "deprecated marker",
+			section:      &Section{Title: "Old feature [DEPRECATED]"},
+			expected:     LifecycleDeprecated,
+			requiresTest: false,
+		},
+		{
+			name:         "manual marker",
+			section:      &Section{Title: "Printed manual [MANUAL]"},
+			expected:     LifecycleManual,
+			requiresTest: false,
+		},
+		{
+			name:         "won't fix marker",
+			section:      &Section{Title: "IE6 support [WONTFIX]"},
+			expected:     LifecycleWontFix,
+			requiresTest: false,
+		},
+		{
+			name:         "inherited from parent",
+			section:      newChild("Upcoming [DRAFT]", nil),
+			expected:     LifecycleDraft,
+			requiresTest: false,
+		},
+		{
+			name:         "from front matter status",
+			section:      newChild("Feature", &Metadata{Status: "draft"}),
+			expected:     LifecycleDraft,
+			requiresTest: false,
+		},
+		{
+			name:         "unrelated front matter status",
+			section:      newChild("Feature", &Metadata{Status: "approved"}),
+			expected:     LifecycleActive,
+			requiresTest: true,
+		},
+		{
+			name:         "markers are case-sensitive",
+			section:      &Section{Title: "Feature [draft]"},
+			expected:     LifecycleActive,
+			requiresTest: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := tt.section.Lifecycle(); result != tt.expected {
+				t.Errorf("Lifecycle() = %q, want %q", result, tt.expected)
+			}
+			if result := tt.section.RequiresTest(); result != tt.requiresTest {
+				t.Errorf("RequiresTest() = %v, want %v", result, tt.requiresTest)
+			}
+		})
+	}
+}
+
+func TestSection_AllTags(t *testing.T) {
+	metadata := &Metadata{Tags: []string{"Billing"}}
+	root := &Section{Title: "Payments [TAGS: api, PCI]", Metadata: metadata}
+	group := &Section{Title: "Refunds", Tags: []string{"security"}, Parent: root, Metadata: metadata}
+	leaf := &Section{Title: "Partial refund [TAG: slow]", Tags: []string{"api"}, Parent: group, Metadata: metadata}
+	root.Children = []*Section{group}
+	group.Children = []*Section{leaf}
+
+	expected := []string{"api", "slow", "billing", "security", "pci"}
+	tags := leaf.AllTags()
+	if len(tags) != len(expected) {
+		t.Fatalf("AllTags() = %q, want %q", tags, expected)
+	}
+	for i := range expected {
+		if tags[i] != expected[i] {
+			t.Errorf("AllTags()[%d] = %q, want %q", i, tags[i], expected[i])
+		}
+	}
+
+	if !leaf.HasTag("Security") {
+		t.Errorf("HasTag(\"Security\") = false, want true (inherited, case-insensitive)")
+	}
+	if root.HasTag("security") {
+		t.Errorf("root.HasTag(\"security\") = true, tags must not flow up the tree")
+	}
+}
+
+func TestSpecification_ApplyTestPrefixes(t *testing.T) {
+	parser := &Section{Title: "Parser", TestPrefix: "Alge/aligned/internal/parser."}
+	headings := &Section{Title: "Parse headings", TestName: "TestParseHeadings", TestNames: []string{"TestParseHeadings"}, Parent: parser}
+	mixed := &Section{Title: "Show parsed headings", TestName: "TestParseLists", TestNames: []string{"TestParseLists", "/Alge/aligned/cmd/align.TestShow"}, Parent: parser}
+	special := &Section{Title: "Special references", TestNames: []string{"go:TestParseHeadings", "re:TestParse.*", "go:/parser.TestParseLists"}, Parent: parser}
+	demo := &Section{Title: "Demo", TestPrefix: "demo/pkg.", Parent: parser}
+	globs := &Section{Title: "Globs", TestNames: []string{"/pkg.TestParse*", "pkg.TestParse*"}, Parent: demo}
+	python := &Section{Title: "Python", TestPrefix: "tests/test_api.py::", Parent: parser}
+	login := &Section{Title: "Login", TestName: "test_login", TestNames: []string{"test_login", "/integration/test_x.py::t"}, Parent: python}
+	parser.Children = []*Section{headings, mixed, special, demo, python}
+	demo.Children = []*Section{globs}
+	python.Children = []*Section{login}
+	unprefixed := &Section{Title: "Elsewhere", TestName: "TestElsewhere", TestNames: []string{"TestElsewhere", "/TestAbsolute"}}
+
+	specification := &Specification{Sections: []*Section{parser, unprefixed}}
+	specification.ApplyTestPrefixes()
+
+	if headings.TestName != "Alge/aligned/internal/parser.TestParseHeadings" {
+		t.Errorf("TestName = %q, want the prefixed reference", headings.TestName)
+	}
+	if parser.HasTest() || python.HasTest() {
+		t.Errorf("sections without test references should not get one from their prefix")
+	}
+	assertTests := func(section *Section, expected ...string) {
+		t.Helper()
+		tests := section.Tests()
+		if len(tests) != len(expected) {
+			t.Fatalf("%s: Tests() = %q, want %q", section.Title, tests, expected)
+		}
+		for i := range expected {
+			if tests[i] != expected[i] {
+				t.Errorf("%s: Tests()[%d] = %q, want %q", section.Title, i, tests[i], expected[i])
+			}
+		}
+	}
+	assertTests(mixed, "Alge/aligned/internal/parser.TestParseLists", "Alge/aligned/cmd/align.TestShow")
+	assertTests(special, "go:Alge/aligned/internal/parser.TestParseHeadings", "re:TestParse.*", "go:parser.TestParseLists")
+	assertTests(globs, "pkg.TestParse*", "demo/pkg.pkg.TestParse*")
+	assertTests(login, "tests/test_api.py::test_login", "integration/test_x.py::t")
+	assertTests(unprefixed, "TestElsewhere", "TestAbsolute")
+}
@@ -100,4 +100,140 @@ func TestAllowAdditionalSectionsInImplementation(t *testing.T) {
 	
 	missing := ValidateImplementation(impl, iface)
 	assert.Empty(t, missing, "Extra sections should be allowed")
-}
\ No newline at end of file
+}
+
+func TestValidateNestedImplementationStructure(t *testing.T) {
+	iface := &Section{
+		Title: "Connector Interface [INTERFACE]",
+		Children: []*Section{
+			{Title: "Command Integration", Children: []*Section{
+				{Title: "Register in init command"},
+				{Title: "Register in check command"},
+			}},
+			{Title: "Test Discovery", Children: []*Section{
+				{Title: "Discover tests"},
+			}},
+		},
+	}
+
+	t.Run("missing grandchild is reported with its full path", func(t *testing.T) {
+		impl := &Section{
+			Title: "Go Connector [IMPLEMENTS: Connector Interface]",
+			Children: []*Section{
+				{Title: "Command Integration", Children: []*Section{
+					{Title: "Register in check command"},
+				}},
+				{Title: "Test Discovery", Children: []*Section{
+					{Title: "Discover tests"},
+					{Title: "Extra detail"},
+				}},
+			},
+		}
+
+		missing := ValidateImplementation(impl, iface)
+		assert.Equal(t, []string{"command integration > register in init command"}, missing)
+	})
+
+	t.Run("descendants of a missing section are not reported separately", func(t *testing.T) {
+		impl := &Section{
+			Title: "Go Connector [IMPLEMENTS: Connector Interface]",
+			Children: []*Section{
+				{Title: "Command Integration", Children: []*Section{
+					{Title: "Register in init command"},
+					{Title: "Register in check command"},
+				}},
+			},
+		}
+
+		missing := ValidateImplementation(impl, iface)
+		assert.Equal(t, []string{"test discovery"}, missing)
+	})
+}
+
+func TestValidateInterfacesStrict(t *testing.T) {
+	specification := &Specification{Sections: []*Section{
+		{Title: "Database [INTERFACE]", Children: []*Section{
+			{Title: "Connect to database"},
+			{Title: "Queries", Children: []*Section{{Title: "Execute query"}}},
+		}},
+		{Title: "Postgres [IMPLEMENTS: Database]", Children: []*Section{
+			{Title: "Conect to database"},
+			{Title: "Queries", Children: []*Section{
+				{Title: "Execute query", Children: []*Section{{Title: "Free-form detail"}}},
+				{Title: "Vacuum tables"},
+			}},
+		}},
+	}}
+
+	t.Run("extra sections are allowed by default", func(t *testing.T) {
+		errors := specification.ValidateInterfaces()
+		assert.Len(t, errors, 1)
+		assert.Equal(t, []string{"connect to database"}, errors[0].Missing)
+	})
+
+	t.Run("strict mode lists unexpected sections with suggestions", func(t *testing.T) {
+		errors := specification.ValidateInterfacesStrict()
+		assert.Len(t, errors, 2)
+		assert.Equal(t, []UnexpectedSection{
+			{Path: "conect to database", Suggestion: "connect to database"},
+			{Path: "queries > vacuum tables"},
+		}, errors[1].Unexpected)
+		assert.Equal(t, "Postgres [IMPLEMENTS: Database] has unexpected sections: conect to database (did you mean 'connect to database'?), queries > vacuum tables", errors[1].Error())
+	})
+}
+
+func TestValidateOptionalInterfaceSections(t *testing.T) {
+	iface := &Section{
+		Title: "Connector [INTERFACE]",
+		Children: []*Section{
+			{Title: "Discover tests"},
+			{Title: "Watch Mode [OPTIONAL]", Children: []*Section{
+				{Title: "Rerun changed tests"},
+			}},
+		},
+	}
+
+	t.Run("optional sections may be omitted", func(t *testing.T) {
+		impl := &Section{
+			Title:    "Go Connector [IMPLEMENTS: Connector]",
+			Children: []*Section{{Title: "Discover tests"}},
+		}
+		assert.Empty(t, ValidateImplementation(impl, iface))
+	})
+
+	t.Run("included optional sections are matched without the marker", func(t *testing.T) {
+		impl := &Section{
+			Title: "Vitest Connector [IMPLEMENTS: Connector]",
+			Children: []*Section{
+				{Title: "Discover tests"},
+				{Title: "Watch mode"},
+			},
+		}
+		assert.Equal(t, []string{"watch mode > rerun changed tests"}, ValidateImplementation(impl, iface))
+	})
+}
+
+func TestValidateNotApplicableSections(t *testing.T) {
+	iface := &Section{
+		Title: "Connector [INTERFACE]",
+		Children: []*Section{
+			{Title: "Test Discovery", Children: []*Section{
+				{Title: "Handle empty test suite"},
+				{Title: "Handle nested directories"},
+			}},
+		},
+	}
+
+	impl := &Section{
+		Title: "Gleam Connector [IMPLEMENTS: Connector]",
+		Children: []*Section{
+			{Title: "Test Discovery [N/A]", Reason: "Gleam discovers tests at compile time"},
+		},
+	}
+	impl.Children[0].Parent = impl
+
+	assert.True(t, impl.Children[0].IsNotApplicable())
+	assert.Equal(t, LifecycleNotApplicable, impl.Children[0].Lifecycle())
+	assert.False(t, impl.Children[0].RequiresTest(), "an [N/A] section needs a reason instead of a test")
+	assert.Empty(t, ValidateImplementation(impl, iface), "an [N/A] section waives the interface section and its children")
+}